@@ -0,0 +1,164 @@
+package bimap
+
+import (
+	"errors"
+	"iter"
+
+	"github.com/hsiafan/go-utils/lang/optional"
+)
+
+// ErrorValueExists is returned by [BiMap.Put] when the value is already bound to another key.
+var ErrorValueExists = errors.New("bimap: value already bound to another key")
+
+// BiMap is a bidirectional map, which keeps both keys and values unique, so values can also be used to lookup keys.
+//
+// The zero BiMap is empty and ready for use.
+type BiMap[K, V comparable] struct {
+	forward  map[K]V
+	backward map[V]K
+	inverse  *BiMap[V, K]
+}
+
+// New creates a new BiMap.
+func New[K, V comparable]() *BiMap[K, V] {
+	return &BiMap[K, V]{forward: make(map[K]V), backward: make(map[V]K)}
+}
+
+// Inverse returns the inverse view of this BiMap, which maps values to keys.
+// The inverse view shares storage with this BiMap, so changes to one are visible in the other.
+func (b *BiMap[K, V]) Inverse() *BiMap[V, K] {
+	if b.inverse == nil {
+		b.init()
+		b.inverse = &BiMap[V, K]{forward: b.backward, backward: b.forward, inverse: b}
+	}
+	return b.inverse
+}
+
+// GetByKey returns the value for key.
+func (b *BiMap[K, V]) GetByKey(k K) optional.Optional[V] {
+	v, ok := b.forward[k]
+	return optional.Of(v, ok)
+}
+
+// GetByValue returns the key for value.
+func (b *BiMap[K, V]) GetByValue(v V) optional.Optional[K] {
+	k, ok := b.backward[v]
+	return optional.Of(k, ok)
+}
+
+// ContainsKey returns true if key exists.
+func (b *BiMap[K, V]) ContainsKey(k K) bool {
+	_, ok := b.forward[k]
+	return ok
+}
+
+// ContainsValue returns true if value exists.
+func (b *BiMap[K, V]) ContainsValue(v V) bool {
+	_, ok := b.backward[v]
+	return ok
+}
+
+// Put adds or sets value for key. If the value is already bound to another key,
+// the BiMap is not changed and [ErrorValueExists] is returned.
+func (b *BiMap[K, V]) Put(k K, v V) error {
+	if bk, ok := b.backward[v]; ok && bk != k {
+		return ErrorValueExists
+	}
+	b.put(k, v)
+	return nil
+}
+
+// ForcePut adds or sets value for key. If the value is already bound to another key, that key is removed.
+func (b *BiMap[K, V]) ForcePut(k K, v V) {
+	if bk, ok := b.backward[v]; ok && bk != k {
+		delete(b.forward, bk)
+	}
+	b.put(k, v)
+}
+
+func (b *BiMap[K, V]) put(k K, v V) {
+	b.init()
+	if old, ok := b.forward[k]; ok {
+		delete(b.backward, old)
+	}
+	b.forward[k] = v
+	b.backward[v] = k
+}
+
+// init creates the maps of a zero BiMap. It must be called before the maps are written or shared with the inverse view.
+func (b *BiMap[K, V]) init() {
+	if b.forward == nil {
+		b.forward = make(map[K]V)
+		b.backward = make(map[V]K)
+	}
+}
+
+// RemoveByKey removes key and its value.
+func (b *BiMap[K, V]) RemoveByKey(k K) {
+	if v, ok := b.forward[k]; ok {
+		delete(b.forward, k)
+		delete(b.backward, v)
+	}
+}
+
+// RemoveByValue removes value and its key.
+func (b *BiMap[K, V]) RemoveByValue(v V) {
+	if k, ok := b.backward[v]; ok {
+		delete(b.backward, v)
+		delete(b.forward, k)
+	}
+}
+
+// All returns all key-value pairs as a sequence, in unspecified order.
+func (b *BiMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range b.forward {
+			if !yield(k, v) {
+				break
+			}
+		}
+	}
+}
+
+// Keys returns all keys as a sequence, in unspecified order.
+func (b *BiMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range b.forward {
+			if !yield(k) {
+				break
+			}
+		}
+	}
+}
+
+// Values returns all values as a sequence, in unspecified order.
+func (b *BiMap[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for v := range b.backward {
+			if !yield(v) {
+				break
+			}
+		}
+	}
+}
+
+// Copy returns a new BiMap with same key-values.
+func (b *BiMap[K, V]) Copy() *BiMap[K, V] {
+	nb := &BiMap[K, V]{forward: make(map[K]V, len(b.forward)), backward: make(map[V]K, len(b.backward))}
+	for k, v := range b.forward {
+		nb.forward[k] = v
+		nb.backward[v] = k
+	}
+	return nb
+}
+
+// Size returns the size of the map.
+func (b *BiMap[K, V]) Size() int {
+	return len(b.forward)
+}
+
+// Clear clears the map, and its inverse view.
+func (b *BiMap[K, V]) Clear() {
+	clear(b.forward)
+	clear(b.backward)
+}
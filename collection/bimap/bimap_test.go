@@ -0,0 +1,71 @@
+package bimap
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBiMap(t *testing.T) {
+	b := New[int, string]()
+	assert.NoError(t, b.Put(1, "one"))
+	assert.NoError(t, b.Put(2, "two"))
+	assert.Equal(t, "one", b.GetByKey(1).Get())
+	assert.Equal(t, 2, b.GetByValue("two").Get())
+	assert.True(t, b.GetByKey(3).IsEmpty())
+	assert.True(t, b.ContainsKey(1))
+	assert.True(t, b.ContainsValue("one"))
+
+	assert.ErrorIs(t, b.Put(3, "one"), ErrorValueExists)
+	assert.False(t, b.ContainsKey(3))
+	assert.NoError(t, b.Put(1, "one"))
+
+	// replace value of existing key
+	assert.NoError(t, b.Put(1, "uno"))
+	assert.False(t, b.ContainsValue("one"))
+	assert.Equal(t, 1, b.GetByValue("uno").Get())
+
+	// force put removes the old key of value
+	b.ForcePut(3, "two")
+	assert.False(t, b.ContainsKey(2))
+	assert.Equal(t, 3, b.GetByValue("two").Get())
+	assert.Equal(t, 2, b.Size())
+	assert.Equal(t, map[int]string{1: "uno", 3: "two"}, maps.Collect(b.All()))
+
+	b.RemoveByKey(1)
+	b.RemoveByValue("two")
+	assert.Equal(t, 0, b.Size())
+	assert.False(t, b.ContainsValue("uno"))
+}
+
+func TestBiMap_Inverse(t *testing.T) {
+	b := New[int, string]()
+	_ = b.Put(1, "one")
+	inv := b.Inverse()
+	assert.Same(t, b, inv.Inverse())
+	assert.Equal(t, 1, inv.GetByKey("one").Get())
+
+	_ = inv.Put("two", 2)
+	assert.Equal(t, "two", b.GetByKey(2).Get())
+	assert.ElementsMatch(t, []int{1, 2}, slices.Collect(b.Keys()))
+	assert.ElementsMatch(t, []int{1, 2}, slices.Collect(inv.Values()))
+
+	c := b.Copy()
+	b.Clear()
+	assert.Equal(t, 0, inv.Size())
+	assert.Equal(t, 2, c.Size())
+}
+
+func TestBiMap_ZeroValue(t *testing.T) {
+	var b BiMap[string, int]
+	assert.True(t, b.GetByKey("a").IsEmpty())
+	b.RemoveByValue(1)
+	inv := b.Inverse()
+	assert.NoError(t, b.Put("a", 1))
+	assert.Equal(t, "a", inv.GetByKey(1).Get())
+	inv.ForcePut(2, "b")
+	assert.Equal(t, 2, b.GetByKey("b").Get())
+	assert.Equal(t, 2, b.Size())
+}
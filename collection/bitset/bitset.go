@@ -0,0 +1,340 @@
+package bitset
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"iter"
+	"math/bits"
+	"slices"
+
+	"github.com/hsiafan/go-utils/collection"
+	"github.com/hsiafan/go-utils/collection/set"
+	"github.com/hsiafan/go-utils/lang/optional"
+)
+
+const wordSize = 64
+
+// ErrorNegativeIndex is the panic value when a negative index is given, or the error when unmarshal
+// a negative index from JSON.
+var ErrorNegativeIndex = errors.New("bitset: negative index")
+
+// ErrorInvalidBinary is returned when unmarshal a BitSet from binary data with invalid length.
+var ErrorInvalidBinary = errors.New("bitset: binary data length is not a multiple of 8")
+
+// BitSet is a set of non-negative ints, stored as a bit array. It grows automatically when bits are set.
+// Every method panics with [ErrorNegativeIndex] if given a negative index.
+//
+// The zero BitSet is empty and ready for use.
+type BitSet struct {
+	words []uint64
+}
+
+var _ collection.SetView[int] = (*BitSet)(nil)
+
+// New creates a new BitSet, with given bits set.
+func New(values ...int) *BitSet {
+	b := &BitSet{}
+	for _, v := range values {
+		b.Set(v)
+	}
+	return b
+}
+
+// NewWithSize creates a new empty BitSet, with enough space to hold bits in [0, size) without growing.
+func NewWithSize(size int) *BitSet {
+	checkIndex(size)
+	return &BitSet{words: make([]uint64, 0, wordCount(size))}
+}
+
+// FromSet creates a new BitSet contains the elements of a set. It panics if the set contains negative ints.
+func FromSet(s set.Set[int]) *BitSet {
+	b := &BitSet{}
+	for v := range s {
+		b.Set(v)
+	}
+	return b
+}
+
+// ToSet returns a new set contains the set bits.
+func (b *BitSet) ToSet() set.Set[int] {
+	return set.Collect(b.All())
+}
+
+// Set sets bit i to 1.
+func (b *BitSet) Set(i int) {
+	checkIndex(i)
+	b.grow(i + 1)
+	b.words[i/wordSize] |= 1 << (i % wordSize)
+}
+
+// Clear sets bit i to 0.
+func (b *BitSet) Clear(i int) {
+	checkIndex(i)
+	if w := i / wordSize; w < len(b.words) {
+		b.words[w] &^= 1 << (i % wordSize)
+	}
+}
+
+// Flip toggles bit i.
+func (b *BitSet) Flip(i int) {
+	checkIndex(i)
+	b.grow(i + 1)
+	b.words[i/wordSize] ^= 1 << (i % wordSize)
+}
+
+// Test reports whether bit i is set.
+func (b *BitSet) Test(i int) bool {
+	checkIndex(i)
+	w := i / wordSize
+	return w < len(b.words) && b.words[w]&(1<<(i%wordSize)) != 0
+}
+
+// SetRange sets bits in [from, to) to 1.
+func (b *BitSet) SetRange(from, to int) {
+	if b.checkRange(from, to) {
+		b.grow(to)
+		b.applyRange(from, to, func(w *uint64, mask uint64) { *w |= mask })
+	}
+}
+
+// ClearRange sets bits in [from, to) to 0.
+func (b *BitSet) ClearRange(from, to int) {
+	if b.checkRange(from, to) {
+		to = min(to, len(b.words)*wordSize)
+		b.applyRange(from, to, func(w *uint64, mask uint64) { *w &^= mask })
+	}
+}
+
+// FlipRange toggles bits in [from, to).
+func (b *BitSet) FlipRange(from, to int) {
+	if b.checkRange(from, to) {
+		b.grow(to)
+		b.applyRange(from, to, func(w *uint64, mask uint64) { *w ^= mask })
+	}
+}
+
+func (b *BitSet) checkRange(from, to int) bool {
+	checkIndex(from)
+	checkIndex(to)
+	return from < to
+}
+
+// applyRange calls f with each word and the mask of bits in [from, to) of the word.
+func (b *BitSet) applyRange(from, to int, f func(w *uint64, mask uint64)) {
+	for from < to {
+		w := from / wordSize
+		start := from % wordSize
+		end := min(to-w*wordSize, wordSize)
+		mask := ^uint64(0) >> (wordSize - (end - start)) << start
+		f(&b.words[w], mask)
+		from = (w + 1) * wordSize
+	}
+}
+
+// Contains reports whether bit v is set, it is the same as [BitSet.Test].
+func (b *BitSet) Contains(v int) bool {
+	return b.Test(v)
+}
+
+// Count returns the number of set bits.
+func (b *BitSet) Count() int {
+	count := 0
+	for _, w := range b.words {
+		count += bits.OnesCount64(w)
+	}
+	return count
+}
+
+// Size returns the number of set bits, it is the same as [BitSet.Count].
+func (b *BitSet) Size() int {
+	return b.Count()
+}
+
+// Len returns the index of highest set bit plus one, or 0 if no bit is set.
+func (b *BitSet) Len() int {
+	for w := len(b.words) - 1; w >= 0; w-- {
+		if b.words[w] != 0 {
+			return w*wordSize + bits.Len64(b.words[w])
+		}
+	}
+	return 0
+}
+
+// NextSet returns the index of the first set bit at or after i.
+func (b *BitSet) NextSet(i int) optional.Optional[int] {
+	checkIndex(i)
+	w := i / wordSize
+	if w >= len(b.words) {
+		return optional.Empty[int]()
+	}
+	word := b.words[w] >> (i % wordSize)
+	if word != 0 {
+		return optional.OfValue(i + bits.TrailingZeros64(word))
+	}
+	for w++; w < len(b.words); w++ {
+		if b.words[w] != 0 {
+			return optional.OfValue(w*wordSize + bits.TrailingZeros64(b.words[w]))
+		}
+	}
+	return optional.Empty[int]()
+}
+
+// All returns the indexes of set bits as a [iter.Seq], in ascending order.
+func (b *BitSet) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for w := 0; w < len(b.words); w++ {
+			word := b.words[w]
+			for word != 0 {
+				t := bits.TrailingZeros64(word)
+				if !yield(w*wordSize + t) {
+					return
+				}
+				word &= word - 1
+			}
+		}
+	}
+}
+
+// ToSlice returns the indexes of set bits as a slice, in ascending order.
+func (b *BitSet) ToSlice() []int {
+	return slices.AppendSeq(make([]int, 0, b.Count()), b.All())
+}
+
+// Union sets bits which are set in other, in place.
+func (b *BitSet) Union(other *BitSet) {
+	b.grow(len(other.words) * wordSize)
+	for i, w := range other.words {
+		b.words[i] |= w
+	}
+}
+
+// Intersection clears bits which are not set in other, in place.
+func (b *BitSet) Intersection(other *BitSet) {
+	for i := range b.words {
+		if i < len(other.words) {
+			b.words[i] &= other.words[i]
+		} else {
+			b.words[i] = 0
+		}
+	}
+}
+
+// Difference clears bits which are set in other, in place.
+func (b *BitSet) Difference(other *BitSet) {
+	for i := range min(len(b.words), len(other.words)) {
+		b.words[i] &^= other.words[i]
+	}
+}
+
+// Xor toggles bits which are set in other, in place.
+func (b *BitSet) Xor(other *BitSet) {
+	b.grow(len(other.words) * wordSize)
+	for i, w := range other.words {
+		b.words[i] ^= w
+	}
+}
+
+// Equal reports whether the two BitSets have the same bits set.
+func (b *BitSet) Equal(other *BitSet) bool {
+	n := max(len(b.words), len(other.words))
+	for i := range n {
+		if b.word(i) != other.word(i) {
+			return false
+		}
+	}
+	return true
+}
+
+// Copy returns a new BitSet with the same bits set.
+func (b *BitSet) Copy() *BitSet {
+	return &BitSet{words: slices.Clone(b.trimmed())}
+}
+
+// Reset clears all bits.
+func (b *BitSet) Reset() {
+	clear(b.words)
+}
+
+// MarshalBinary encodes the BitSet as little-endian 64-bit words, trailing zero words are omitted.
+func (b *BitSet) MarshalBinary() ([]byte, error) {
+	words := b.trimmed()
+	data := make([]byte, 0, len(words)*8)
+	for _, w := range words {
+		data = binary.LittleEndian.AppendUint64(data, w)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary decodes the BitSet from data produced by [BitSet.MarshalBinary].
+func (b *BitSet) UnmarshalBinary(data []byte) error {
+	if len(data)%8 != 0 {
+		return ErrorInvalidBinary
+	}
+	words := make([]uint64, len(data)/8)
+	for i := range words {
+		words[i] = binary.LittleEndian.Uint64(data[i*8:])
+	}
+	b.words = words
+	return nil
+}
+
+// MarshalJSON encodes the BitSet as a JSON array of set bit indexes, in ascending order.
+func (b *BitSet) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.ToSlice())
+}
+
+// UnmarshalJSON decodes the BitSet from a JSON array of bit indexes, replacing the existing bits.
+// A JSON null is a no-op.
+func (b *BitSet) UnmarshalJSON(data []byte) error {
+	var values []int
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	if values == nil {
+		return nil
+	}
+	for _, v := range values {
+		if v < 0 {
+			return ErrorNegativeIndex
+		}
+	}
+	b.Reset()
+	for _, v := range values {
+		b.Set(v)
+	}
+	return nil
+}
+
+func (b *BitSet) word(i int) uint64 {
+	if i < len(b.words) {
+		return b.words[i]
+	}
+	return 0
+}
+
+// trimmed returns words without trailing zero words.
+func (b *BitSet) trimmed() []uint64 {
+	n := len(b.words)
+	for n > 0 && b.words[n-1] == 0 {
+		n--
+	}
+	return b.words[:n]
+}
+
+// grow makes sure the words can hold n bits.
+func (b *BitSet) grow(n int) {
+	if c := wordCount(n); c > len(b.words) {
+		b.words = append(b.words, make([]uint64, c-len(b.words))...)
+	}
+}
+
+func wordCount(n int) int {
+	return (n + wordSize - 1) / wordSize
+}
+
+func checkIndex(i int) {
+	if i < 0 {
+		panic(ErrorNegativeIndex)
+	}
+}
@@ -0,0 +1,129 @@
+package bitset
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/hsiafan/go-utils/collection/set"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBitSet(t *testing.T) {
+	var b BitSet
+	assert.False(t, b.Test(100))
+	b.Set(1)
+	b.Set(64)
+	b.Set(130)
+	assert.True(t, b.Test(1))
+	assert.True(t, b.Test(64))
+	assert.False(t, b.Test(2))
+	assert.Equal(t, 3, b.Count())
+	assert.Equal(t, 131, b.Len())
+
+	b.Clear(64)
+	b.Clear(1000)
+	assert.False(t, b.Test(64))
+	b.Flip(2)
+	b.Flip(1)
+	assert.Equal(t, []int{2, 130}, b.ToSlice())
+
+	assert.Equal(t, 2, b.NextSet(0).Get())
+	assert.Equal(t, 130, b.NextSet(3).Get())
+	assert.True(t, b.NextSet(131).IsEmpty())
+	assert.True(t, b.NextSet(1000).IsEmpty())
+
+	b.Reset()
+	assert.Equal(t, 0, b.Count())
+	assert.Equal(t, 0, b.Len())
+
+	assert.PanicsWithValue(t, ErrorNegativeIndex, func() { b.Set(-1) })
+}
+
+func TestBitSet_Range(t *testing.T) {
+	b := NewWithSize(10)
+	b.SetRange(3, 7)
+	assert.Equal(t, []int{3, 4, 5, 6}, b.ToSlice())
+	b.SetRange(60, 200)
+	assert.Equal(t, 144, b.Count())
+	b.ClearRange(62, 199)
+	assert.Equal(t, []int{3, 4, 5, 6, 60, 61, 199}, b.ToSlice())
+	b.ClearRange(0, 1000)
+	assert.Equal(t, 0, b.Count())
+
+	b.FlipRange(0, 64)
+	b.FlipRange(32, 96)
+	assert.Equal(t, 64, b.Count())
+	assert.True(t, b.Test(31))
+	assert.False(t, b.Test(32))
+	assert.True(t, b.Test(95))
+
+	b.SetRange(5, 5)
+	b.SetRange(10, 5)
+	assert.Equal(t, 64, b.Count())
+}
+
+func TestBitSet_Algebra(t *testing.T) {
+	b := New(1, 2, 100)
+	b.Union(New(3, 200))
+	assert.Equal(t, []int{1, 2, 3, 100, 200}, b.ToSlice())
+
+	b.Intersection(New(1, 3, 100))
+	assert.Equal(t, []int{1, 3, 100}, b.ToSlice())
+
+	b.Difference(New(3, 500))
+	assert.Equal(t, []int{1, 100}, b.ToSlice())
+
+	b.Xor(New(1, 2, 300))
+	assert.Equal(t, []int{2, 100, 300}, b.ToSlice())
+
+	assert.True(t, b.Equal(New(300, 2, 100)))
+	c := b.Copy()
+	c.Clear(300)
+	assert.False(t, b.Equal(c))
+	assert.True(t, c.Equal(New(2, 100)))
+}
+
+func TestBitSet_Iterate(t *testing.T) {
+	b := New(0, 63, 64, 127, 128)
+	assert.Equal(t, []int{0, 63, 64, 127, 128}, slices.Collect(b.All()))
+	var first []int
+	for i := range b.All() {
+		first = append(first, i)
+		if len(first) == 2 {
+			break
+		}
+	}
+	assert.Equal(t, []int{0, 63}, first)
+}
+
+func TestBitSet_Encoding(t *testing.T) {
+	b := New(1, 65, 1000)
+	b.Clear(1000)
+	data, err := b.MarshalBinary()
+	assert.NoError(t, err)
+	assert.Equal(t, 16, len(data))
+
+	var nb BitSet
+	assert.NoError(t, nb.UnmarshalBinary(data))
+	assert.True(t, nb.Equal(b))
+	assert.ErrorIs(t, nb.UnmarshalBinary([]byte{1, 2, 3}), ErrorInvalidBinary)
+
+	js, err := json.Marshal(b)
+	assert.NoError(t, err)
+	assert.Equal(t, "[1,65]", string(js))
+	var jb BitSet
+	assert.NoError(t, json.Unmarshal([]byte("[3,5]"), &jb))
+	assert.Equal(t, []int{3, 5}, jb.ToSlice())
+	assert.ErrorIs(t, json.Unmarshal([]byte("[-1]"), &jb), ErrorNegativeIndex)
+
+	js, err = json.Marshal(New())
+	assert.NoError(t, err)
+	assert.Equal(t, "[]", string(js))
+}
+
+func TestBitSet_Set(t *testing.T) {
+	b := FromSet(set.New(1, 5, 70))
+	assert.Equal(t, []int{1, 5, 70}, b.ToSlice())
+	assert.Equal(t, set.New(1, 5, 70), b.ToSet())
+}
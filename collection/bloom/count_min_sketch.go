@@ -0,0 +1,132 @@
+package bloom
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// CountMinSketch estimates frequencies of values in a stream, using sub-linear space.
+// The estimated count of a value is never less than the real count; with probability 1 - delta,
+// it exceeds the real count by at most epsilon * total count.
+//
+// The zero CountMinSketch is not usable, use [NewCountMinSketch] or [NewCountMinSketchWithSize] to create one.
+// A CountMinSketch is not safe for concurrent use; sketches counted separately can be combined by [CountMinSketch.Merge].
+type CountMinSketch[T any] struct {
+	counts []uint64 // depth rows of width counters
+	width  uint64
+	depth  uint64
+	total  uint64
+	hash   Hasher[T]
+}
+
+// NewCountMinSketch creates a new CountMinSketch with error bound epsilon and failure probability delta,
+// both should be in (0, 1).
+func NewCountMinSketch[T any](epsilon, delta float64, hash Hasher[T]) *CountMinSketch[T] {
+	if epsilon <= 0 || epsilon >= 1 || delta <= 0 || delta >= 1 {
+		panic(fmt.Sprintf("bloom: invalid sketch parameters epsilon=%v, delta=%v", epsilon, delta))
+	}
+	width := math.Ceil(math.E / epsilon)
+	depth := math.Ceil(math.Log(1 / delta))
+	return NewCountMinSketchWithSize(int(width), int(depth), hash)
+}
+
+// NewCountMinSketchWithSize creates a new CountMinSketch with depth rows of width counters.
+func NewCountMinSketchWithSize[T any](width, depth int, hash Hasher[T]) *CountMinSketch[T] {
+	if width <= 0 || depth <= 0 {
+		panic(fmt.Sprintf("bloom: invalid sketch size width=%d, depth=%d", width, depth))
+	}
+	return &CountMinSketch[T]{
+		counts: make([]uint64, width*depth),
+		width:  uint64(width),
+		depth:  uint64(depth),
+		hash:   hash,
+	}
+}
+
+// Add adds n occurrences of value.
+func (s *CountMinSketch[T]) Add(v T, n uint64) {
+	h1, h2 := locations(s.hash(v))
+	for i := uint64(0); i < s.depth; i++ {
+		s.counts[i*s.width+(h1+i*h2)%s.width] += n
+	}
+	s.total += n
+}
+
+// Count returns the estimated number of occurrences of value.
+func (s *CountMinSketch[T]) Count(v T) uint64 {
+	h1, h2 := locations(s.hash(v))
+	count := uint64(math.MaxUint64)
+	for i := uint64(0); i < s.depth; i++ {
+		count = min(count, s.counts[i*s.width+(h1+i*h2)%s.width])
+	}
+	return count
+}
+
+// Total returns the total number of occurrences added.
+func (s *CountMinSketch[T]) Total() uint64 {
+	return s.total
+}
+
+// Merge adds all counts of another sketch into this sketch. The sketches must have the same size
+// and use the same hasher, otherwise [ErrorIncompatible] is returned.
+func (s *CountMinSketch[T]) Merge(other *CountMinSketch[T]) error {
+	if s.width != other.width || s.depth != other.depth {
+		return ErrorIncompatible
+	}
+	for i, c := range other.counts {
+		s.counts[i] += c
+	}
+	s.total += other.total
+	return nil
+}
+
+// Width returns the number of counters in each row.
+func (s *CountMinSketch[T]) Width() int {
+	return int(s.width)
+}
+
+// Depth returns the number of rows.
+func (s *CountMinSketch[T]) Depth() int {
+	return int(s.depth)
+}
+
+// Clear resets all counts to zero.
+func (s *CountMinSketch[T]) Clear() {
+	clear(s.counts)
+	s.total = 0
+}
+
+// MarshalBinary encodes the sketch size and counts. The hasher is not encoded.
+func (s *CountMinSketch[T]) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, 24+len(s.counts)*8)
+	data = binary.LittleEndian.AppendUint64(data, s.width)
+	data = binary.LittleEndian.AppendUint64(data, s.depth)
+	data = binary.LittleEndian.AppendUint64(data, s.total)
+	for _, c := range s.counts {
+		data = binary.LittleEndian.AppendUint64(data, c)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary decodes the sketch from data produced by [CountMinSketch.MarshalBinary], replacing the size and counts.
+// The sketch keeps its hasher, which should be the same as the hasher of the encoded sketch.
+func (s *CountMinSketch[T]) UnmarshalBinary(data []byte) error {
+	if len(data) < 24 {
+		return ErrorInvalidBinary
+	}
+	width := binary.LittleEndian.Uint64(data)
+	depth := binary.LittleEndian.Uint64(data[8:])
+	total := binary.LittleEndian.Uint64(data[16:])
+	data = data[24:]
+	n := uint64(len(data) / 8)
+	if len(data)%8 != 0 || width == 0 || n%width != 0 || n/width != depth {
+		return ErrorInvalidBinary
+	}
+	counts := make([]uint64, n)
+	for i := range counts {
+		counts[i] = binary.LittleEndian.Uint64(data[i*8:])
+	}
+	s.counts, s.width, s.depth, s.total = counts, width, depth, total
+	return nil
+}
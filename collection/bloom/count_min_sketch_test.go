@@ -0,0 +1,48 @@
+package bloom
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountMinSketch(t *testing.T) {
+	s := NewCountMinSketch(0.001, 0.01, StringHasher)
+	assert.Equal(t, 2719, s.Width())
+	assert.Equal(t, 5, s.Depth())
+	for i := 0; i < 1000; i++ {
+		s.Add(strconv.Itoa(i), uint64(i%10+1))
+	}
+	s.Add("hot", 10000)
+	assert.Equal(t, uint64(15500), s.Total())
+	exceeded := 0
+	for i := 0; i < 1000; i++ {
+		expected := uint64(i%10 + 1)
+		count := s.Count(strconv.Itoa(i))
+		assert.GreaterOrEqual(t, count, expected)
+		if count > expected+16 { // epsilon * total
+			exceeded++
+		}
+	}
+	assert.Less(t, exceeded, 10) // delta * 1000
+	assert.Equal(t, uint64(10000), s.Count("hot"))
+
+	s2 := NewCountMinSketch(0.001, 0.01, StringHasher)
+	s2.Add("hot", 5)
+	assert.NoError(t, s.Merge(s2))
+	assert.Equal(t, uint64(10005), s.Count("hot"))
+	assert.ErrorIs(t, s.Merge(NewCountMinSketchWithSize(10, 5, StringHasher)), ErrorIncompatible)
+
+	data, err := s.MarshalBinary()
+	assert.NoError(t, err)
+	s3 := NewCountMinSketchWithSize(1, 1, StringHasher)
+	assert.NoError(t, s3.UnmarshalBinary(data))
+	assert.Equal(t, s.Total(), s3.Total())
+	assert.Equal(t, uint64(10005), s3.Count("hot"))
+	assert.ErrorIs(t, s3.UnmarshalBinary(data[:len(data)-8]), ErrorInvalidBinary)
+
+	s.Clear()
+	assert.Equal(t, uint64(0), s.Count("hot"))
+	assert.Equal(t, uint64(0), s.Total())
+}
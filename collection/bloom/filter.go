@@ -0,0 +1,133 @@
+package bloom
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/hsiafan/go-utils/collection/bitset"
+)
+
+// ErrorIncompatible is returned when combining filters or sketches with different parameters.
+var ErrorIncompatible = errors.New("bloom: incompatible parameters")
+
+// ErrorInvalidBinary is returned when unmarshaling from malformed binary data.
+var ErrorInvalidBinary = errors.New("bloom: invalid binary data")
+
+// Filter is a bloom filter, a space-efficient probabilistic set. Testing a value may return false positives,
+// but never false negatives.
+//
+// The zero Filter is not usable, use [New] or [NewWithSize] to create one.
+// A Filter is not safe for concurrent use. To fill it from many goroutines, give each one its own Filter
+// of the same size, and combine them by [Filter.Union].
+type Filter[T any] struct {
+	bits *bitset.BitSet
+	m    uint64 // number of bits
+	k    uint64 // number of hash functions
+	hash Hasher[T]
+}
+
+// New creates a new Filter, sized for holding n values with false positive rate fpRate, 0 < fpRate < 1.
+func New[T any](n int, fpRate float64, hash Hasher[T]) *Filter[T] {
+	if fpRate <= 0 || fpRate >= 1 {
+		panic(fmt.Sprintf("bloom: invalid false positive rate %v", fpRate))
+	}
+	n = max(n, 1)
+	m := math.Ceil(-float64(n) * math.Log(fpRate) / (math.Ln2 * math.Ln2))
+	k := math.Round(m / float64(n) * math.Ln2)
+	return NewWithSize(int(m), max(int(k), 1), hash)
+}
+
+// NewWithSize creates a new Filter with m bits and k hash functions.
+func NewWithSize[T any](m, k int, hash Hasher[T]) *Filter[T] {
+	if m <= 0 || k <= 0 {
+		panic(fmt.Sprintf("bloom: invalid filter size m=%d, k=%d", m, k))
+	}
+	return &Filter[T]{bits: bitset.NewWithSize(m), m: uint64(m), k: uint64(k), hash: hash}
+}
+
+// Add adds a value to the filter.
+func (f *Filter[T]) Add(v T) {
+	h1, h2 := locations(f.hash(v))
+	for i := uint64(0); i < f.k; i++ {
+		f.bits.Set(int((h1 + i*h2) % f.m))
+	}
+}
+
+// Test reports whether the value may be in the filter. A false result means the value is definitely not added.
+func (f *Filter[T]) Test(v T) bool {
+	h1, h2 := locations(f.hash(v))
+	for i := uint64(0); i < f.k; i++ {
+		if !f.bits.Test(int((h1 + i*h2) % f.m)) {
+			return false
+		}
+	}
+	return true
+}
+
+// Union adds all values of another filter into this filter. The filters must have the same size
+// and use the same hasher, otherwise [ErrorIncompatible] is returned.
+func (f *Filter[T]) Union(other *Filter[T]) error {
+	if f.m != other.m || f.k != other.k {
+		return ErrorIncompatible
+	}
+	f.bits.Union(other.bits)
+	return nil
+}
+
+// EstimatedCount returns the approximate number of distinct values added to the filter.
+func (f *Filter[T]) EstimatedCount() int {
+	x := float64(f.bits.Count())
+	m := float64(f.m)
+	if x >= m {
+		return math.MaxInt
+	}
+	return int(math.Round(-m / float64(f.k) * math.Log(1-x/m)))
+}
+
+// Bits returns the number of bits in the filter.
+func (f *Filter[T]) Bits() int {
+	return int(f.m)
+}
+
+// Hashes returns the number of hash functions used by the filter.
+func (f *Filter[T]) Hashes() int {
+	return int(f.k)
+}
+
+// Clear removes all values from the filter.
+func (f *Filter[T]) Clear() {
+	f.bits.Reset()
+}
+
+// MarshalBinary encodes the filter parameters and bits. The hasher is not encoded.
+func (f *Filter[T]) MarshalBinary() ([]byte, error) {
+	bits, err := f.bits.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	data := make([]byte, 0, 16+len(bits))
+	data = binary.LittleEndian.AppendUint64(data, f.m)
+	data = binary.LittleEndian.AppendUint64(data, f.k)
+	return append(data, bits...), nil
+}
+
+// UnmarshalBinary decodes the filter from data produced by [Filter.MarshalBinary], replacing the parameters and bits.
+// The filter keeps its hasher, which should be the same as the hasher of the encoded filter.
+func (f *Filter[T]) UnmarshalBinary(data []byte) error {
+	if len(data) < 16 {
+		return ErrorInvalidBinary
+	}
+	m := binary.LittleEndian.Uint64(data)
+	k := binary.LittleEndian.Uint64(data[8:])
+	if m == 0 || k == 0 || m > math.MaxInt {
+		return ErrorInvalidBinary
+	}
+	bits := &bitset.BitSet{}
+	if err := bits.UnmarshalBinary(data[16:]); err != nil || uint64(bits.Len()) > m {
+		return ErrorInvalidBinary
+	}
+	f.bits, f.m, f.k = bits, m, k
+	return nil
+}
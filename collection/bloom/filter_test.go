@@ -0,0 +1,76 @@
+package bloom
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilter(t *testing.T) {
+	f := New(1000, 0.01, StringHasher)
+	assert.Equal(t, 7, f.Hashes())
+	assert.Equal(t, 9586, f.Bits())
+	for i := 0; i < 1000; i++ {
+		f.Add(strconv.Itoa(i))
+	}
+	for i := 0; i < 1000; i++ {
+		assert.True(t, f.Test(strconv.Itoa(i)))
+	}
+	falsePositives := 0
+	for i := 1000; i < 11000; i++ {
+		if f.Test(strconv.Itoa(i)) {
+			falsePositives++
+		}
+	}
+	assert.Less(t, falsePositives, 200)
+	assert.InDelta(t, 1000, f.EstimatedCount(), 50)
+
+	f.Clear()
+	assert.False(t, f.Test("1"))
+	assert.Equal(t, 0, f.EstimatedCount())
+}
+
+func TestFilter_Comparable(t *testing.T) {
+	type point struct{ x, y int }
+	f := New(100, 0.001, ComparableHasher[point]())
+	f.Add(point{1, 2})
+	assert.True(t, f.Test(point{1, 2}))
+	assert.False(t, f.Test(point{2, 1}))
+
+	b := New(100, 0.001, BytesHasher)
+	b.Add([]byte("abc"))
+	assert.True(t, b.Test([]byte("abc")))
+	assert.False(t, b.Test([]byte("abd")))
+}
+
+func TestFilter_Union(t *testing.T) {
+	f1 := New(100, 0.01, StringHasher)
+	f2 := New(100, 0.01, StringHasher)
+	f1.Add("a")
+	f2.Add("b")
+	assert.NoError(t, f1.Union(f2))
+	assert.True(t, f1.Test("a"))
+	assert.True(t, f1.Test("b"))
+	assert.ErrorIs(t, f1.Union(New(200, 0.01, StringHasher)), ErrorIncompatible)
+}
+
+func TestFilter_Binary(t *testing.T) {
+	f := New(100, 0.01, StringHasher)
+	f.Add("a")
+	f.Add("b")
+	data, err := f.MarshalBinary()
+	assert.NoError(t, err)
+
+	f2 := NewWithSize(1, 1, StringHasher)
+	assert.NoError(t, f2.UnmarshalBinary(data))
+	assert.Equal(t, f.Bits(), f2.Bits())
+	assert.Equal(t, f.Hashes(), f2.Hashes())
+	assert.True(t, f2.Test("a"))
+	assert.True(t, f2.Test("b"))
+	assert.False(t, f2.Test("c"))
+
+	assert.ErrorIs(t, f2.UnmarshalBinary(data[:10]), ErrorInvalidBinary)
+	assert.ErrorIs(t, f2.UnmarshalBinary(data[:len(data)-1]), ErrorInvalidBinary)
+	assert.ErrorIs(t, f2.UnmarshalBinary(make([]byte, 16)), ErrorInvalidBinary)
+}
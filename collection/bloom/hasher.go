@@ -0,0 +1,48 @@
+package bloom
+
+import (
+	"hash/fnv"
+	"hash/maphash"
+)
+
+// Hasher computes a 64-bit hash of value, it is used by [Filter] and [CountMinSketch] to locate value.
+//
+// To persist a Filter or CountMinSketch by MarshalBinary and use it in another process,
+// the hasher must be stable across processes, like [BytesHasher] and [StringHasher].
+type Hasher[T any] func(v T) uint64
+
+// BytesHasher hashes byte slices with FNV-1a, it is stable across processes.
+func BytesHasher(v []byte) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write(v)
+	return h.Sum64()
+}
+
+// StringHasher hashes strings with FNV-1a, it is stable across processes.
+func StringHasher(v string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(v))
+	return h.Sum64()
+}
+
+// ComparableHasher returns a Hasher for any comparable values, implemented by [maphash.Comparable].
+// The hasher uses a random seed, so hashes differ between hashers and between processes;
+// filters built with it can only be combined and unmarshaled with the same hasher.
+func ComparableHasher[T comparable]() Hasher[T] {
+	seed := maphash.MakeSeed()
+	return func(v T) uint64 {
+		return maphash.Comparable(seed, v)
+	}
+}
+
+// locations returns two hashes of value for double hashing, the i-th location is h1 + i*h2.
+func locations(h uint64) (uint64, uint64) {
+	// h2 is derived by the splitmix64 finalizer, and forced to be odd so that it is never zero
+	h2 := h
+	h2 ^= h2 >> 30
+	h2 *= 0xbf58476d1ce4e5b9
+	h2 ^= h2 >> 27
+	h2 *= 0x94d049bb133111eb
+	h2 ^= h2 >> 31
+	return h, h2 | 1
+}
@@ -0,0 +1,213 @@
+package bqueue
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"sync"
+
+	"github.com/hsiafan/go-utils/collection/deque"
+)
+
+// ErrorClosed is returned when putting to or taking from a closed queue.
+var ErrorClosed = errors.New("bqueue: queue is closed")
+
+// Queue is a concurrent-safe blocking FIFO queue implemented by [deque.Deque], for producer/consumer pipelines.
+// A Queue may be bounded, then [Queue.Put] blocks when the queue is full; or unbounded, then Put never blocks.
+//
+// [Queue.Close] drains the queue: it returns the remaining elements to the caller, and after that
+// no more elements can be put or taken.
+//
+// The zero Queue is an unbounded empty queue and ready for use. A Queue must not be copied after first use.
+type Queue[T any] struct {
+	mu       sync.Mutex
+	items    deque.Deque[T]
+	capacity int // max number of elements, 0 for unbounded
+	closed   bool
+	// changed is closed and reset to nil when the queue changes, to wake up all waiting goroutines.
+	// It is created lazily only when some goroutine is waiting.
+	changed chan struct{}
+}
+
+// New creates a new unbounded Queue.
+func New[T any]() *Queue[T] {
+	return &Queue[T]{}
+}
+
+// NewBounded creates a new Queue which holds at most capacity elements. A non-positive capacity means unbounded.
+func NewBounded[T any](capacity int) *Queue[T] {
+	return &Queue[T]{capacity: max(capacity, 0)}
+}
+
+// Put adds an element to the back of the queue, blocks while the queue is full.
+// It returns [ErrorClosed] if the queue is closed, or ctx.Err() if ctx is done before the element could be added.
+func (q *Queue[T]) Put(ctx context.Context, v T) error {
+	q.mu.Lock()
+	for {
+		if q.closed {
+			q.mu.Unlock()
+			return ErrorClosed
+		}
+		if !q.full() {
+			q.items.PushBack(v)
+			q.signal()
+			q.mu.Unlock()
+			return nil
+		}
+		if err := q.wait(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+// TryPut adds an element to the back of the queue without blocking,
+// returns false if the queue is full or closed.
+func (q *Queue[T]) TryPut(v T) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed || q.full() {
+		return false
+	}
+	q.items.PushBack(v)
+	q.signal()
+	return true
+}
+
+// Take removes and returns the front element of the queue, blocks while the queue is empty.
+// It returns [ErrorClosed] if the queue is closed, or ctx.Err() if ctx is done before an element is available.
+func (q *Queue[T]) Take(ctx context.Context) (T, error) {
+	q.mu.Lock()
+	for {
+		if q.closed {
+			q.mu.Unlock()
+			var zero T
+			return zero, ErrorClosed
+		}
+		if v, ok := q.items.PopFront(); ok {
+			q.signal()
+			q.mu.Unlock()
+			return v, nil
+		}
+		if err := q.wait(ctx); err != nil {
+			var zero T
+			return zero, err
+		}
+	}
+}
+
+// TryTake removes and returns the front element of the queue without blocking,
+// returns false if the queue is empty.
+func (q *Queue[T]) TryTake() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	v, ok := q.items.PopFront()
+	if ok {
+		q.signal()
+	}
+	return v, ok
+}
+
+// Peek returns the front element of the queue without removing it, returns false if the queue is empty.
+func (q *Queue[T]) Peek() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.items.PeekFront()
+}
+
+// Drain removes and returns all elements in the queue without blocking, in FIFO order.
+// It returns nil if the queue is empty.
+func (q *Queue[T]) Drain() []T {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.drain()
+}
+
+// drain removes and returns all elements. It must be called with lock held.
+func (q *Queue[T]) drain() []T {
+	if q.items.Size() == 0 {
+		return nil
+	}
+	values := make([]T, 0, q.items.Size())
+	for v := range q.items.Values() {
+		values = append(values, v)
+	}
+	q.items.Clear()
+	q.signal()
+	return values
+}
+
+// Close closes and drains the queue, returns the remaining elements in FIFO order, or nil if the queue is empty.
+// Puts and takes after Close fail with [ErrorClosed], and blocked puts and takes are woken up and fail.
+// Close is idempotent, closing a closed queue returns nil.
+func (q *Queue[T]) Close() []T {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return nil
+	}
+	q.closed = true
+	remained := q.drain()
+	q.signal()
+	return remained
+}
+
+// Closed reports whether the queue has been closed.
+func (q *Queue[T]) Closed() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.closed
+}
+
+// Seq returns a sequence which takes elements from the queue, until the queue is closed or ctx is done.
+// Each element is consumed by only one of the goroutines ranging over the queue.
+func (q *Queue[T]) Seq(ctx context.Context) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			v, err := q.Take(ctx)
+			if err != nil || !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Size returns the number of elements in the queue.
+func (q *Queue[T]) Size() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.items.Size()
+}
+
+// Cap returns the max number of elements the queue can hold, 0 for an unbounded queue.
+func (q *Queue[T]) Cap() int {
+	return q.capacity
+}
+
+func (q *Queue[T]) full() bool {
+	return q.capacity > 0 && q.items.Size() >= q.capacity
+}
+
+// signal wakes up all waiting goroutines. It must be called with lock held.
+func (q *Queue[T]) signal() {
+	if q.changed != nil {
+		close(q.changed)
+		q.changed = nil
+	}
+}
+
+// wait releases the lock, waits until the queue changes or ctx is done. It must be called with lock held,
+// and holds the lock again when returns nil; when ctx is done, it returns ctx.Err() without holding the lock.
+func (q *Queue[T]) wait(ctx context.Context) error {
+	if q.changed == nil {
+		q.changed = make(chan struct{})
+	}
+	changed := q.changed
+	q.mu.Unlock()
+	select {
+	case <-changed:
+		q.mu.Lock()
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
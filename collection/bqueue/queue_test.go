@@ -0,0 +1,176 @@
+package bqueue
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueue(t *testing.T) {
+	ctx := context.Background()
+	var q Queue[int]
+	assert.NoError(t, q.Put(ctx, 1))
+	assert.True(t, q.TryPut(2))
+	assert.True(t, q.TryPut(3))
+	assert.Equal(t, 3, q.Size())
+	assert.Equal(t, 0, q.Cap())
+
+	v, ok := q.Peek()
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+	v, err := q.Take(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, v)
+	v, ok = q.TryTake()
+	assert.True(t, ok)
+	assert.Equal(t, 2, v)
+
+	assert.Equal(t, []int{3}, q.Drain())
+	assert.Nil(t, q.Drain())
+	_, ok = q.TryTake()
+	assert.False(t, ok)
+}
+
+func TestQueue_Bounded(t *testing.T) {
+	ctx := context.Background()
+	q := NewBounded[int](2)
+	assert.True(t, q.TryPut(1))
+	assert.True(t, q.TryPut(2))
+	assert.False(t, q.TryPut(3))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert.NoError(t, q.Put(ctx, 3))
+	}()
+	select {
+	case <-done:
+		t.Fatal("put should block on full queue")
+	case <-time.After(20 * time.Millisecond):
+	}
+	v, err := q.Take(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, v)
+	<-done
+	assert.Equal(t, []int{2, 3}, q.Drain())
+}
+
+func TestQueue_Cancel(t *testing.T) {
+	q := NewBounded[int](1)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := q.Take(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	q.TryPut(1)
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, q.Put(ctx, 2), context.Canceled)
+	assert.Equal(t, 1, q.Size())
+}
+
+func TestQueue_Close(t *testing.T) {
+	ctx := context.Background()
+	q := NewBounded[int](1)
+	q.TryPut(1)
+
+	errs := make(chan error)
+	go func() {
+		errs <- q.Put(ctx, 2)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	// remaining elements are drained and returned by Close
+	assert.Equal(t, []int{1}, q.Close())
+	assert.Nil(t, q.Close())
+	assert.True(t, q.Closed())
+	assert.ErrorIs(t, <-errs, ErrorClosed)
+	assert.False(t, q.TryPut(3))
+	assert.Equal(t, 0, q.Size())
+	_, err := q.Take(ctx)
+	assert.ErrorIs(t, err, ErrorClosed)
+
+	// blocked takes are woken up and fail
+	q = New[int]()
+	go func() {
+		_, err := q.Take(ctx)
+		errs <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	assert.Nil(t, q.Close())
+	assert.ErrorIs(t, <-errs, ErrorClosed)
+}
+
+func TestQueue_Seq(t *testing.T) {
+	ctx := context.Background()
+	q := NewBounded[int](4)
+	remained := make(chan []int, 1)
+	go func() {
+		for i := 0; i < 100; i++ {
+			_ = q.Put(ctx, i)
+		}
+		remained <- q.Close()
+	}()
+	var values []int
+	for v := range q.Seq(ctx) {
+		values = append(values, v)
+	}
+	// the elements not taken before Close are returned by Close
+	values = append(values, <-remained...)
+	assert.Len(t, values, 100)
+	assert.True(t, slices.IsSorted(values))
+
+	q2 := New[int]()
+	q2.TryPut(1)
+	q2.TryPut(2)
+	for v := range q2.Seq(ctx) {
+		assert.Equal(t, 1, v)
+		break
+	}
+	assert.Equal(t, 1, q2.Size())
+}
+
+func TestQueue_Concurrent(t *testing.T) {
+	ctx := context.Background()
+	q := NewBounded[int](8)
+	const producers, consumers, count = 4, 4, 1000
+
+	var producerWg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		producerWg.Add(1)
+		go func() {
+			defer producerWg.Done()
+			for i := 0; i < count; i++ {
+				assert.NoError(t, q.Put(ctx, 1))
+			}
+		}()
+	}
+	remained := make(chan []int, 1)
+	go func() {
+		producerWg.Wait()
+		remained <- q.Close()
+	}()
+
+	sums := make([]int, consumers)
+	var consumerWg sync.WaitGroup
+	for c := 0; c < consumers; c++ {
+		consumerWg.Add(1)
+		go func() {
+			defer consumerWg.Done()
+			for v := range q.Seq(ctx) {
+				sums[c] += v
+			}
+		}()
+	}
+	consumerWg.Wait()
+
+	total := len(<-remained)
+	for _, s := range sums {
+		total += s
+	}
+	assert.Equal(t, producers*count, total)
+	assert.Equal(t, 0, q.Size())
+}
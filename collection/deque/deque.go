@@ -1,50 +1,76 @@
 package deque
 
 import (
+	"fmt"
 	"iter"
 )
 
+// minCapacity is the capacity allocated when the first element is pushed into an empty deque,
+// and the least capacity the deque shrinks to.
+const minCapacity = 16
+
 // Deque represents a [Deque] implemented using a dynamic array (circular buffer).
+//
+// The zero Deque is empty and ready for use. The buffer grows when it is full, and shrinks by half
+// when only a quarter of it is used, so pushing and popping around a size boundary does not cause
+// repeated resizing. Slots of removed elements are zeroed, so the deque does not keep references to them.
+//
+// A Deque is not safe for concurrent use, see bqueue.Queue for a concurrent-safe blocking queue built on it.
 type Deque[T any] struct {
-	data  []T
-	front int
-	rear  int
-	size  int
+	data    []T
+	head    int  // position in data of the front element
+	size    int  // number of elements
+	minCap  int  // the deque never shrinks below this capacity
+	bounded bool // ring-buffer mode, the capacity is fixed and the oldest element is overwritten on overflow
 }
 
 // New creates a new empty deque.
 func New[T any]() *Deque[T] {
-	return &Deque[T]{
-		data: make([]T, 16),
-		rear: -1,
-	}
+	return &Deque[T]{}
 }
 
 // NewWithSize creates a new empty deque with a specified initial capacity.
+// The deque never shrinks below the initial capacity. A non-positive capacity is treated as zero.
 func NewWithSize[T any](capacity int) *Deque[T] {
+	capacity = max(capacity, 0)
 	return &Deque[T]{
-		data: make([]T, capacity),
-		rear: -1,
+		data:   make([]T, capacity),
+		minCap: capacity,
+	}
+}
+
+// NewRing creates a new empty deque in ring-buffer mode, with a fixed capacity.
+// When the deque is full, pushing a new element overwrites the element at the other end:
+// [Deque.PushBack] drops the front element, and [Deque.PushFront] drops the back element.
+// It is useful for keeping the last N elements, e.g. the last N log lines.
+func NewRing[T any](capacity int) *Deque[T] {
+	if capacity <= 0 {
+		panic(fmt.Sprintf("deque: invalid ring capacity %d", capacity))
+	}
+	return &Deque[T]{
+		data:    make([]T, capacity),
+		minCap:  capacity,
+		bounded: true,
 	}
 }
 
 // PushFront adds an element to the front of the deque.
+// In ring-buffer mode, if the deque is full, the back element is dropped.
 func (d *Deque[T]) PushFront(value T) {
-	if d.size == len(d.data) {
-		d.resize(len(d.data) * 2)
+	d.makeRoom(true)
+	d.head--
+	if d.head < 0 {
+		d.head += len(d.data)
 	}
-	d.front = (d.front - 1 + len(d.data)) % len(d.data)
-	d.data[d.front] = value
+	d.data[d.head] = value
 	d.size++
 }
 
 // PushBack adds an element to the back of the deque.
+// In ring-buffer mode, if the deque is full, the front element is dropped.
 func (d *Deque[T]) PushBack(value T) {
-	if d.size == len(d.data) {
-		d.resize(len(d.data) * 2)
-	}
-	d.rear = (d.rear + 1) % len(d.data)
-	d.data[d.rear] = value
+	d.makeRoom(false)
+	d.data[d.index(d.size)] = value
 	d.size++
 }
 
@@ -54,12 +80,11 @@ func (d *Deque[T]) PopFront() (T, bool) {
 	if d.size == 0 {
 		return zero, false
 	}
-	value := d.data[d.front]
-	d.front = (d.front + 1) % len(d.data)
+	value := d.data[d.head]
+	d.data[d.head] = zero
+	d.head = d.index(1)
 	d.size--
-	if d.size > 0 && d.size <= len(d.data)/4 {
-		d.resize(len(d.data) / 2)
-	}
+	d.shrinkIfSparse()
 	return value, true
 }
 
@@ -69,38 +94,179 @@ func (d *Deque[T]) PopBack() (T, bool) {
 	if d.size == 0 {
 		return zero, false
 	}
-	value := d.data[d.rear]
-	d.rear = (d.rear - 1 + len(d.data)) % len(d.data)
+	i := d.index(d.size - 1)
+	value := d.data[i]
+	d.data[i] = zero
 	d.size--
-	if d.size > 0 && d.size <= len(d.data)/4 {
-		d.resize(len(d.data) / 2)
-	}
+	d.shrinkIfSparse()
 	return value, true
 }
 
-// resize resizes the underlying array to a new capacity.
-func (d *Deque[T]) resize(newCapacity int) {
-	newData := make([]T, newCapacity)
-	for i := 0; i < d.size; i++ {
-		newData[i] = d.data[(d.front+i)%len(d.data)]
+// PeekFront returns the element at the front of the deque without removing it.
+func (d *Deque[T]) PeekFront() (T, bool) {
+	var zero T
+	if d.size == 0 {
+		return zero, false
+	}
+	return d.data[d.head], true
+}
+
+// PeekBack returns the element at the back of the deque without removing it.
+func (d *Deque[T]) PeekBack() (T, bool) {
+	var zero T
+	if d.size == 0 {
+		return zero, false
+	}
+	return d.data[d.index(d.size-1)], true
+}
+
+// Get returns the element at index i, the front element has index 0. It panics if i is out of range.
+func (d *Deque[T]) Get(i int) T {
+	d.checkIndex(i, d.size)
+	return d.data[d.index(i)]
+}
+
+// Set sets the element at index i, the front element has index 0. It panics if i is out of range.
+func (d *Deque[T]) Set(i int, value T) {
+	d.checkIndex(i, d.size)
+	d.data[d.index(i)] = value
+}
+
+// Insert inserts an element at index i, so that it has index i after insertion; 0 <= i <= Size().
+// Elements are shifted from the nearer end of the deque. It panics if i is out of range.
+// In ring-buffer mode, if the deque is full, the front element is dropped first, and the new element
+// is inserted before the element it would have been inserted before.
+func (d *Deque[T]) Insert(i int, value T) {
+	d.checkIndex(i, d.size+1)
+	if d.bounded && d.size == len(d.data) {
+		d.PopFront()
+		if i == 0 {
+			d.PushFront(value)
+			return
+		}
+		i--
+	}
+	if i < d.size/2 {
+		d.PushFront(value)
+		for j := 0; j < i; j++ {
+			d.data[d.index(j)] = d.data[d.index(j+1)]
+		}
+	} else {
+		d.PushBack(value)
+		for j := d.size - 1; j > i; j-- {
+			d.data[d.index(j)] = d.data[d.index(j-1)]
+		}
+	}
+	d.data[d.index(i)] = value
+}
+
+// RemoveAt removes and returns the element at index i. Elements are shifted from the nearer end of the deque.
+// It panics if i is out of range.
+func (d *Deque[T]) RemoveAt(i int) T {
+	d.checkIndex(i, d.size)
+	value := d.data[d.index(i)]
+	if i < d.size/2 {
+		for j := i; j > 0; j-- {
+			d.data[d.index(j)] = d.data[d.index(j-1)]
+		}
+		d.PopFront()
+	} else {
+		for j := i; j < d.size-1; j++ {
+			d.data[d.index(j)] = d.data[d.index(j+1)]
+		}
+		d.PopBack()
+	}
+	return value
+}
+
+// Clear removes all elements from the deque.
+func (d *Deque[T]) Clear() {
+	clear(d.data)
+	d.head = 0
+	d.size = 0
+}
+
+// index returns the position in data of the element at index i, 0 <= i <= len(d.data).
+func (d *Deque[T]) index(i int) int {
+	p := d.head + i
+	if p >= len(d.data) {
+		p -= len(d.data)
+	}
+	return p
+}
+
+func (d *Deque[T]) checkIndex(i int, limit int) {
+	if i < 0 || i >= limit {
+		panic(fmt.Sprintf("deque: index %d out of range [0, %d)", i, limit))
+	}
+}
+
+// makeRoom makes sure there is room for one more element. In ring-buffer mode, a full deque drops
+// the back element when pushing to front, and drops the front element when pushing to back.
+func (d *Deque[T]) makeRoom(pushFront bool) {
+	if d.size < len(d.data) {
+		return
+	}
+	if d.bounded {
+		if pushFront {
+			d.PopBack()
+		} else {
+			d.PopFront()
+		}
+		return
+	}
+	d.resize(max(len(d.data)*2, minCapacity))
+}
+
+// resize moves the elements to a new buffer with given capacity.
+func (d *Deque[T]) resize(capacity int) {
+	data := make([]T, capacity)
+	if d.size > 0 {
+		n := copy(data, d.data[d.head:min(d.head+d.size, len(d.data))])
+		copy(data[n:], d.data[:d.size-n])
+	}
+	d.data = data
+	d.head = 0
+}
+
+// shrinkIfSparse halves the buffer when only a quarter of it is used.
+func (d *Deque[T]) shrinkIfSparse() {
+	if d.bounded {
+		return
+	}
+	floor := max(d.minCap, minCapacity)
+	if len(d.data) > floor && d.size <= len(d.data)/4 {
+		d.resize(max(len(d.data)/2, floor))
 	}
-	d.data = newData
-	d.front = 0
-	d.rear = d.size - 1
 }
 
 // Values returns all values in current Deque as a [iter.Seq].
 func (d *Deque[T]) Values() iter.Seq[T] {
 	return func(yield func(T) bool) {
 		for i := 0; i < d.size; i++ {
-			v := d.data[(d.front+i)%len(d.data)]
-			if !yield(v) {
+			if !yield(d.data[d.index(i)]) {
+				break
+			}
+		}
+	}
+}
+
+// Backward returns all values in current Deque as a [iter.Seq], from back to front.
+func (d *Deque[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := d.size - 1; i >= 0; i-- {
+			if !yield(d.data[d.index(i)]) {
 				break
 			}
 		}
 	}
 }
 
+// Cap returns the capacity of the deque. In ring-buffer mode, it is the fixed max number of elements.
+func (d *Deque[T]) Cap() int {
+	return len(d.data)
+}
+
 // Size returns the number of elements in the deque.
 func (d *Deque[T]) Size() int {
 	return d.size
@@ -117,3 +117,260 @@ func TestResizing(t *testing.T) {
 	}
 	assert.Equal(t, 2, deque.Size(), "Size should be 2 after resizing down")
 }
+
+func TestDeque_RandomAccess(t *testing.T) {
+	deque := New[int]()
+	for i := 0; i < 5; i++ {
+		deque.PushBack(i)
+	}
+	deque.PushFront(-1)
+	assert.Equal(t, -1, deque.Get(0))
+	assert.Equal(t, 4, deque.Get(5))
+	deque.Set(1, 10)
+	assert.Equal(t, []int{-1, 10, 1, 2, 3, 4}, slices.Collect(deque.Values()))
+	assert.Panics(t, func() { deque.Get(6) })
+	assert.Panics(t, func() { deque.Set(-1, 0) })
+
+	front, ok := deque.PeekFront()
+	assert.True(t, ok)
+	assert.Equal(t, -1, front)
+	back, ok := deque.PeekBack()
+	assert.True(t, ok)
+	assert.Equal(t, 4, back)
+	assert.Equal(t, 6, deque.Size())
+
+	assert.Equal(t, []int{4, 3, 2, 1, 10, -1}, slices.Collect(deque.Backward()))
+
+	deque.Clear()
+	assert.Equal(t, 0, deque.Size())
+	_, ok = deque.PeekFront()
+	assert.False(t, ok)
+	_, ok = deque.PeekBack()
+	assert.False(t, ok)
+	deque.PushBack(1)
+	assert.Equal(t, []int{1}, slices.Collect(deque.Values()))
+}
+
+func TestDeque_InsertAndRemoveAt(t *testing.T) {
+	deque := New[int]()
+	var model []int
+	for i := 0; i < 40; i++ {
+		pos := (i * 7) % (len(model) + 1)
+		deque.Insert(pos, i)
+		model = slices.Insert(model, pos, i)
+		assert.Equal(t, model, slices.Collect(deque.Values()))
+	}
+	for i := 0; len(model) > 0; i++ {
+		pos := (i * 5) % len(model)
+		if i%4 == 3 {
+			pos = len(model) - 1
+		}
+		assert.Equal(t, model[pos], deque.RemoveAt(pos))
+		model = slices.Delete(model, pos, pos+1)
+		assert.Equal(t, model, append([]int{}, slices.Collect(deque.Values())...))
+	}
+	assert.Panics(t, func() { deque.RemoveAt(0) })
+	assert.Panics(t, func() { deque.Insert(1, 0) })
+}
+
+func TestDeque_RemoveAtWrapped(t *testing.T) {
+	// elements pushed to front are stored at the end of the buffer, so the deque wraps around
+	for pos := 0; pos < 10; pos++ {
+		deque := New[int]()
+		for i := 4; i >= 0; i-- {
+			deque.PushFront(i)
+		}
+		for i := 5; i < 10; i++ {
+			deque.PushBack(i)
+		}
+		assert.Greater(t, deque.head+deque.size, len(deque.data))
+		model := slices.Collect(deque.Values())
+		assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, model)
+		assert.Equal(t, pos, deque.RemoveAt(pos))
+		assert.Equal(t, slices.Delete(model, pos, pos+1), slices.Collect(deque.Values()))
+	}
+}
+
+func TestDeque_Ring(t *testing.T) {
+	ring := NewRing[int](3)
+	for i := 0; i < 5; i++ {
+		ring.PushBack(i)
+	}
+	assert.Equal(t, 3, ring.Size())
+	assert.Equal(t, 3, ring.Cap())
+	assert.Equal(t, []int{2, 3, 4}, slices.Collect(ring.Values()))
+
+	ring.PushFront(1)
+	assert.Equal(t, []int{1, 2, 3}, slices.Collect(ring.Values()))
+
+	ring.Insert(2, 10)
+	assert.Equal(t, []int{2, 10, 3}, slices.Collect(ring.Values()))
+	ring.Insert(0, 20)
+	assert.Equal(t, []int{20, 10, 3}, slices.Collect(ring.Values()))
+
+	ring.PopFront()
+	ring.PopFront()
+	assert.Equal(t, 3, ring.Cap())
+	ring.Insert(0, 0)
+	assert.Equal(t, []int{0, 3}, slices.Collect(ring.Values()))
+
+	assert.Panics(t, func() { NewRing[int](0) })
+}
+
+func TestDeque_ZeroValue(t *testing.T) {
+	var deque Deque[int]
+	deque.PushBack(1)
+	deque.PushFront(0)
+	assert.Equal(t, []int{0, 1}, slices.Collect(deque.Values()))
+
+	var deque2 Deque[int]
+	deque2.PushFront(1)
+	value, ok := deque2.PopBack()
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+
+	for _, capacity := range []int{-1, 0, 1, 3, 7} {
+		d := NewWithSize[int](capacity)
+		for i := 0; i < 20; i++ {
+			d.PushBack(i)
+		}
+		assert.Equal(t, 20, d.Size())
+		assert.Equal(t, 19, d.Get(19))
+	}
+}
+
+func TestDeque_ZeroPoppedSlots(t *testing.T) {
+	deque := New[*int]()
+	for i := 0; i < 4; i++ {
+		deque.PushBack(&i)
+	}
+	deque.PopFront()
+	deque.PopBack()
+	deque.RemoveAt(0)
+	var live int
+	for _, p := range deque.data {
+		if p != nil {
+			live++
+		}
+	}
+	assert.Equal(t, 1, live)
+
+	deque.Clear()
+	for _, p := range deque.data {
+		assert.Nil(t, p)
+	}
+}
+
+func TestDeque_Hysteresis(t *testing.T) {
+	deque := NewWithSize[int](20)
+	for i := 0; i < 128; i++ {
+		deque.PushBack(i)
+	}
+	assert.Equal(t, 160, deque.Cap())
+	for deque.Size() > 40 {
+		deque.PopFront()
+	}
+	assert.Equal(t, 80, deque.Cap())
+	// pushing and popping around the boundary does not resize
+	for i := 0; i < 10; i++ {
+		deque.PushBack(i)
+		deque.PopFront()
+		assert.Equal(t, 80, deque.Cap())
+	}
+	for deque.Size() > 0 {
+		deque.PopBack()
+	}
+	// never shrinks below initial capacity
+	assert.Equal(t, 20, deque.Cap())
+}
+
+// applyOps applies operations decoded from ops to the deque and a slice model, and checks they are the same.
+func applyOps(t *testing.T, d *Deque[int], ops []byte, ringCap int) {
+	var model []int
+	for i, op := range ops {
+		arg := int(op >> 3)
+		switch op % 8 {
+		case 0, 1:
+			d.PushBack(i)
+			model = append(model, i)
+			if ringCap > 0 && len(model) > ringCap {
+				model = model[1:]
+			}
+		case 2:
+			d.PushFront(i)
+			model = append([]int{i}, model...)
+			if ringCap > 0 && len(model) > ringCap {
+				model = model[:len(model)-1]
+			}
+		case 3:
+			v, ok := d.PopFront()
+			assert.Equal(t, len(model) > 0, ok)
+			if ok {
+				assert.Equal(t, model[0], v)
+				model = model[1:]
+			}
+		case 4:
+			v, ok := d.PopBack()
+			assert.Equal(t, len(model) > 0, ok)
+			if ok {
+				assert.Equal(t, model[len(model)-1], v)
+				model = model[:len(model)-1]
+			}
+		case 5:
+			pos := arg % (len(model) + 1)
+			d.Insert(pos, i)
+			model = slices.Insert(model, pos, i)
+			if ringCap > 0 && len(model) > ringCap {
+				if pos == 0 {
+					model = slices.Delete(model, 1, 2)
+				} else {
+					model = model[1:]
+				}
+			}
+		case 6:
+			if len(model) > 0 {
+				pos := arg % len(model)
+				assert.Equal(t, model[pos], d.RemoveAt(pos))
+				model = slices.Delete(model, pos, pos+1)
+			}
+		case 7:
+			if len(model) > 0 {
+				pos := arg % len(model)
+				d.Set(pos, -i)
+				model[pos] = -i
+				assert.Equal(t, -i, d.Get(pos))
+			}
+		}
+		if !assert.Equal(t, len(model), d.Size()) {
+			return
+		}
+		assert.Equal(t, append([]int{}, model...), append([]int{}, slices.Collect(d.Values())...))
+		if ringCap > 0 {
+			assert.Equal(t, ringCap, d.Cap())
+		}
+	}
+	reversed := slices.Clone(model)
+	slices.Reverse(reversed)
+	assert.Equal(t, append([]int{}, reversed...), append([]int{}, slices.Collect(d.Backward())...))
+}
+
+func FuzzDeque(f *testing.F) {
+	f.Add(uint8(0), []byte{0, 1, 2, 3, 4, 5, 6, 7})
+	f.Add(uint8(1), []byte{2, 2, 2, 0, 0, 3, 3, 3, 4, 45, 46, 47})
+	f.Add(uint8(3), []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3})
+	f.Fuzz(func(t *testing.T, capacity uint8, ops []byte) {
+		d := NewWithSize[int](int(capacity % 20))
+		applyOps(t, d, ops, 0)
+		var zero Deque[int]
+		applyOps(t, &zero, ops, 0)
+	})
+}
+
+func FuzzDequeRing(f *testing.F) {
+	f.Add(uint8(1), []byte{0, 1, 2, 3, 4, 5, 6, 7})
+	f.Add(uint8(3), []byte{0, 0, 0, 0, 2, 2, 5, 13, 21, 6, 7, 0, 0})
+	f.Fuzz(func(t *testing.T, capacity uint8, ops []byte) {
+		ringCap := int(capacity%8) + 1
+		applyOps(t, NewRing[int](ringCap), ops, ringCap)
+	})
+}
@@ -0,0 +1,468 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hsiafan/go-utils/collection/linkedmap"
+	"github.com/hsiafan/go-utils/collection/pqueue"
+	"github.com/hsiafan/go-utils/lang/optional"
+)
+
+// ErrorCycle is the error matched by [CycleError], returned when sorting a graph with cycles.
+var ErrorCycle = errors.New("graph: cycle detected")
+
+// CycleError is returned by [Graph.TopoSort] when the graph has cycles, it contains one of the cycles.
+// errors.Is(err, ErrorCycle) reports true for a CycleError.
+type CycleError[N comparable] struct {
+	// Cycle is the nodes of the cycle, each node has an edge to the next one, and the last node has an edge to the first.
+	Cycle []N
+}
+
+func (e *CycleError[N]) Error() string {
+	var sb strings.Builder
+	sb.WriteString(ErrorCycle.Error())
+	sb.WriteString(": ")
+	for _, n := range e.Cycle {
+		fmt.Fprint(&sb, n)
+		sb.WriteString(" -> ")
+	}
+	fmt.Fprint(&sb, e.Cycle[0])
+	return sb.String()
+}
+
+// Unwrap returns [ErrorCycle].
+func (e *CycleError[N]) Unwrap() error {
+	return ErrorCycle
+}
+
+// Edge is a directed weighted edge.
+type Edge[N comparable] struct {
+	From   N
+	To     N
+	Weight float64
+}
+
+// Graph is a directed graph with weighted edges. Nodes and the edges from a node are kept in insertion order,
+// so all traversals and algorithms give deterministic results.
+//
+// The zero Graph is not usable, use [New] to create one. The Graph should not be modified during iteration.
+// Queries and algorithms only read the Graph, so they may run concurrently,
+// but not with adding or removing nodes and edges.
+type Graph[N comparable] struct {
+	nodes     *linkedmap.Map[N, *linkedmap.Map[N, float64]] // node to its successors with edge weights
+	edgeCount int
+}
+
+// New creates a new empty Graph.
+func New[N comparable]() *Graph[N] {
+	return &Graph[N]{nodes: linkedmap.New[N, *linkedmap.Map[N, float64]]()}
+}
+
+// AddNode adds a node without edges, it does nothing if the node already exists.
+func (g *Graph[N]) AddNode(n N) {
+	g.successors(n)
+}
+
+// successors returns the successors of node, adds the node if not exists.
+func (g *Graph[N]) successors(n N) *linkedmap.Map[N, float64] {
+	if succ, ok := g.nodes.Get(n).Unwrap(); ok {
+		return succ
+	}
+	succ := linkedmap.New[N, float64]()
+	g.nodes.Put(n, succ)
+	return succ
+}
+
+// AddEdge adds an edge with weight 1 from one node to another, the nodes are added if not exist.
+// If the edge already exists, its weight is set to 1.
+func (g *Graph[N]) AddEdge(from, to N) {
+	g.AddWeightedEdge(from, to, 1)
+}
+
+// AddWeightedEdge adds an edge with weight from one node to another, the nodes are added if not exist.
+// If the edge already exists, its weight is updated. It panics if weight is negative or NaN.
+func (g *Graph[N]) AddWeightedEdge(from, to N, weight float64) {
+	if weight < 0 || math.IsNaN(weight) {
+		panic(fmt.Sprintf("graph: invalid edge weight %v", weight))
+	}
+	succ := g.successors(from)
+	g.AddNode(to)
+	if !succ.Contains(to) {
+		g.edgeCount++
+	}
+	succ.Put(to, weight)
+}
+
+// RemoveEdge removes the edge, returns true if the edge existed.
+func (g *Graph[N]) RemoveEdge(from, to N) bool {
+	succ, ok := g.nodes.Get(from).Unwrap()
+	if !ok || !succ.Contains(to) {
+		return false
+	}
+	succ.Remove(to)
+	g.edgeCount--
+	return true
+}
+
+// RemoveNode removes the node and all edges from or to it, returns true if the node existed.
+// It takes O(V) time to remove the edges to the node.
+func (g *Graph[N]) RemoveNode(n N) bool {
+	succ, ok := g.nodes.Get(n).Unwrap()
+	if !ok {
+		return false
+	}
+	g.edgeCount -= succ.Size()
+	g.nodes.Remove(n)
+	for _, s := range g.nodes.All() {
+		if s.Contains(n) {
+			s.Remove(n)
+			g.edgeCount--
+		}
+	}
+	return true
+}
+
+// HasNode reports whether the node exists.
+func (g *Graph[N]) HasNode(n N) bool {
+	return g.nodes.Contains(n)
+}
+
+// HasEdge reports whether the edge exists.
+func (g *Graph[N]) HasEdge(from, to N) bool {
+	return g.Weight(from, to).IsPresent()
+}
+
+// Weight returns the weight of the edge.
+func (g *Graph[N]) Weight(from, to N) optional.Optional[float64] {
+	succ, ok := g.nodes.Get(from).Unwrap()
+	if !ok {
+		return optional.Empty[float64]()
+	}
+	return succ.Get(to)
+}
+
+// Nodes returns all nodes as a [iter.Seq], in insertion order.
+func (g *Graph[N]) Nodes() iter.Seq[N] {
+	return g.nodes.Keys()
+}
+
+// Successors returns the nodes which the node has edges to, as a [iter.Seq] in insertion order of the edges.
+func (g *Graph[N]) Successors(n N) iter.Seq[N] {
+	return func(yield func(N) bool) {
+		succ, ok := g.nodes.Get(n).Unwrap()
+		if !ok {
+			return
+		}
+		for s := range succ.Keys() {
+			if !yield(s) {
+				break
+			}
+		}
+	}
+}
+
+// Edges returns all edges as a [iter.Seq], ordered by the insertion order of from nodes, then of edges.
+func (g *Graph[N]) Edges() iter.Seq[Edge[N]] {
+	return func(yield func(Edge[N]) bool) {
+		for from, succ := range g.nodes.All() {
+			for to, w := range succ.All() {
+				if !yield(Edge[N]{From: from, To: to, Weight: w}) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// NodeCount returns the number of nodes.
+func (g *Graph[N]) NodeCount() int {
+	return g.nodes.Size()
+}
+
+// EdgeCount returns the number of edges.
+func (g *Graph[N]) EdgeCount() int {
+	return g.edgeCount
+}
+
+// TopoSort returns all nodes in topological order, so that for each edge, the from node comes before the to node.
+// Among the nodes ready to output, the earliest inserted one comes first.
+// If the graph has cycles, it returns a [*CycleError] contains one of the cycles.
+func (g *Graph[N]) TopoSort() ([]N, error) {
+	index := make(map[N]int, g.nodes.Size()) // node to its insertion index
+	inDegree := make(map[N]int, g.nodes.Size())
+	for from, succ := range g.nodes.All() {
+		index[from] = len(index)
+		for to := range succ.Keys() {
+			inDegree[to]++
+		}
+	}
+	ready := pqueue.NewFunc(func(a, b N) bool { return index[a] < index[b] })
+	for n := range g.nodes.Keys() {
+		if inDegree[n] == 0 {
+			ready.Push(n)
+		}
+	}
+	result := make([]N, 0, g.nodes.Size())
+	for n := range ready.Drain() {
+		result = append(result, n)
+		for to := range g.nodes.Get(n).Get().Keys() {
+			inDegree[to]--
+			if inDegree[to] == 0 {
+				ready.Push(to)
+			}
+		}
+	}
+	if len(result) < g.nodes.Size() {
+		return nil, &CycleError[N]{Cycle: g.findCycle(inDegree)}
+	}
+	return result, nil
+}
+
+// findCycle finds a cycle in the nodes remained with positive in-degree after Kahn's algorithm.
+// Each remained node has an edge from another remained node, so following these edges backward finds a cycle.
+func (g *Graph[N]) findCycle(inDegree map[N]int) []N {
+	pred := make(map[N]N)
+	var start N
+	found := false
+	for from, succ := range g.nodes.All() {
+		if inDegree[from] == 0 {
+			continue
+		}
+		if !found {
+			start, found = from, true
+		}
+		for to := range succ.Keys() {
+			if _, ok := pred[to]; !ok && inDegree[to] > 0 {
+				pred[to] = from
+			}
+		}
+	}
+
+	visited := make(map[N]int) // node to its position in walk
+	var walk []N
+	n := start
+	for {
+		if i, ok := visited[n]; ok {
+			walk = walk[i:]
+			break
+		}
+		visited[n] = len(walk)
+		walk = append(walk, n)
+		n = pred[n]
+	}
+	slices.Reverse(walk)
+
+	// rotate so the cycle starts with the earliest inserted node
+	pos := make(map[N]int, len(walk))
+	for i, n := range walk {
+		pos[n] = i
+	}
+	for n := range g.nodes.Keys() {
+		if i, ok := pos[n]; ok {
+			return append(walk[i:], walk[:i]...)
+		}
+	}
+	return walk
+}
+
+// StronglyConnectedComponents returns the strongly connected components of the graph, by Tarjan's algorithm.
+// The components are in reverse topological order: if there is an edge from component A to component B,
+// B comes before A.
+func (g *Graph[N]) StronglyConnectedComponents() [][]N {
+	type frame struct {
+		n    N
+		succ []N
+		next int
+	}
+	index := make(map[N]int, g.nodes.Size())
+	low := make(map[N]int, g.nodes.Size())
+	onStack := make(map[N]bool)
+	var stack []N
+	var frames []*frame
+	var components [][]N
+
+	visit := func(n N) {
+		index[n] = len(index)
+		low[n] = index[n]
+		stack = append(stack, n)
+		onStack[n] = true
+		frames = append(frames, &frame{n: n, succ: slices.Collect(g.nodes.Get(n).Get().Keys())})
+	}
+	for root := range g.nodes.Keys() {
+		if _, ok := index[root]; ok {
+			continue
+		}
+		visit(root)
+		for len(frames) > 0 {
+			f := frames[len(frames)-1]
+			if f.next < len(f.succ) {
+				to := f.succ[f.next]
+				f.next++
+				if _, ok := index[to]; !ok {
+					visit(to)
+				} else if onStack[to] {
+					low[f.n] = min(low[f.n], index[to])
+				}
+				continue
+			}
+			frames = frames[:len(frames)-1]
+			if len(frames) > 0 {
+				parent := frames[len(frames)-1].n
+				low[parent] = min(low[parent], low[f.n])
+			}
+			if low[f.n] == index[f.n] {
+				i := len(stack) - 1
+				for stack[i] != f.n {
+					i--
+				}
+				component := slices.Clone(stack[i:])
+				for _, n := range component {
+					delete(onStack, n)
+				}
+				stack = stack[:i]
+				components = append(components, component)
+			}
+		}
+	}
+	return components
+}
+
+// BFS returns nodes reachable from start in breadth-first order as a [iter.Seq], start node included.
+// It yields nothing if start node not exists.
+func (g *Graph[N]) BFS(start N) iter.Seq[N] {
+	return func(yield func(N) bool) {
+		if !g.nodes.Contains(start) {
+			return
+		}
+		visited := map[N]bool{start: true}
+		queue := []N{start}
+		for len(queue) > 0 {
+			n := queue[0]
+			queue = queue[1:]
+			if !yield(n) {
+				return
+			}
+			for to := range g.nodes.Get(n).Get().Keys() {
+				if !visited[to] {
+					visited[to] = true
+					queue = append(queue, to)
+				}
+			}
+		}
+	}
+}
+
+// DFS returns nodes reachable from start in depth-first pre-order as a [iter.Seq], start node included.
+// It yields nothing if start node not exists.
+func (g *Graph[N]) DFS(start N) iter.Seq[N] {
+	return func(yield func(N) bool) {
+		if !g.nodes.Contains(start) {
+			return
+		}
+		visited := map[N]bool{}
+		stack := []N{start}
+		for len(stack) > 0 {
+			n := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if visited[n] {
+				continue
+			}
+			visited[n] = true
+			if !yield(n) {
+				return
+			}
+			// push in reverse order, so successors are visited in insertion order
+			succ := slices.Collect(g.nodes.Get(n).Get().Keys())
+			for i := len(succ) - 1; i >= 0; i-- {
+				if !visited[succ[i]] {
+					stack = append(stack, succ[i])
+				}
+			}
+		}
+	}
+}
+
+// ShortestPath finds the path with least total weight from one node to another by Dijkstra's algorithm.
+// It returns the nodes of the path, from and to included, and the total weight;
+// ok is false if to node is not reachable from the from node.
+func (g *Graph[N]) ShortestPath(from, to N) (path []N, distance float64, ok bool) {
+	if !g.nodes.Contains(from) || !g.nodes.Contains(to) {
+		return nil, 0, false
+	}
+	type item struct {
+		n    N
+		dist float64
+	}
+	queue := pqueue.NewFunc(func(a, b item) bool { return a.dist < b.dist })
+	handles := map[N]*pqueue.Handle[item]{from: queue.Push(item{from, 0})}
+	done := make(map[N]float64)
+	prev := make(map[N]N)
+	for cur := range queue.Drain() {
+		done[cur.n] = cur.dist
+		if cur.n == to {
+			break
+		}
+		for next, w := range g.nodes.Get(cur.n).Get().All() {
+			if _, ok := done[next]; ok {
+				continue
+			}
+			dist := cur.dist + w
+			if h, ok := handles[next]; !ok {
+				handles[next] = queue.Push(item{next, dist})
+			} else if dist < h.Value().dist {
+				queue.Update(h, item{next, dist})
+			} else {
+				continue
+			}
+			prev[next] = cur.n
+		}
+	}
+	distance, ok = done[to]
+	if !ok {
+		return nil, 0, false
+	}
+	for n := to; n != from; n = prev[n] {
+		path = append(path, n)
+	}
+	path = append(path, from)
+	slices.Reverse(path)
+	return path, distance, true
+}
+
+// DOT returns the graph in Graphviz DOT language. Nodes are formatted by fmt.Sprint,
+// and edges with weight other than 1 are labeled with the weight.
+func (g *Graph[N]) DOT() string {
+	var sb strings.Builder
+	sb.WriteString("digraph {\n")
+	for n := range g.nodes.Keys() {
+		sb.WriteString("  ")
+		sb.WriteString(quote(n))
+		sb.WriteString(";\n")
+	}
+	for e := range g.Edges() {
+		sb.WriteString("  ")
+		sb.WriteString(quote(e.From))
+		sb.WriteString(" -> ")
+		sb.WriteString(quote(e.To))
+		if e.Weight != 1 {
+			sb.WriteString(" [label=")
+			sb.WriteString(strconv.Quote(strconv.FormatFloat(e.Weight, 'g', -1, 64)))
+			sb.WriteString("]")
+		}
+		sb.WriteString(";\n")
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// quote formats a node as a DOT quoted id.
+func quote(n any) string {
+	s := strings.ReplaceAll(fmt.Sprint(n), `\`, `\\`)
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
@@ -0,0 +1,156 @@
+package graph
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraph(t *testing.T) {
+	g := New[string]()
+	g.AddEdge("a", "b")
+	g.AddEdge("a", "c")
+	g.AddWeightedEdge("b", "c", 2.5)
+	g.AddNode("d")
+	g.AddEdge("a", "b")
+	assert.Equal(t, 4, g.NodeCount())
+	assert.Equal(t, 3, g.EdgeCount())
+	assert.True(t, g.HasEdge("a", "b"))
+	assert.False(t, g.HasEdge("b", "a"))
+	assert.Equal(t, 2.5, g.Weight("b", "c").Get())
+	assert.True(t, g.Weight("x", "c").IsEmpty())
+	assert.Equal(t, []string{"a", "b", "c", "d"}, slices.Collect(g.Nodes()))
+	assert.Equal(t, []string{"b", "c"}, slices.Collect(g.Successors("a")))
+	assert.Empty(t, slices.Collect(g.Successors("x")))
+	assert.Equal(t, []Edge[string]{{"a", "b", 1}, {"a", "c", 1}, {"b", "c", 2.5}}, slices.Collect(g.Edges()))
+
+	assert.True(t, g.RemoveEdge("a", "c"))
+	assert.False(t, g.RemoveEdge("a", "c"))
+	assert.Equal(t, 2, g.EdgeCount())
+	assert.True(t, g.RemoveNode("b"))
+	assert.False(t, g.RemoveNode("b"))
+	assert.Equal(t, 0, g.EdgeCount())
+	assert.Equal(t, []string{"a", "c", "d"}, slices.Collect(g.Nodes()))
+
+	assert.Panics(t, func() { g.AddWeightedEdge("a", "c", -1) })
+}
+
+func TestGraph_TopoSort(t *testing.T) {
+	g := New[string]()
+	g.AddNode("app")
+	g.AddEdge("log", "app")
+	g.AddEdge("config", "log")
+	g.AddEdge("config", "db")
+	g.AddEdge("db", "app")
+	g.AddNode("util")
+	sorted, err := g.TopoSort()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"config", "log", "db", "app", "util"}, sorted)
+
+	// ready nodes are ordered by insertion, not by when they become ready
+	order := New[string]()
+	order.AddEdge("a", "c")
+	order.AddNode("b")
+	sorted, err = order.TopoSort()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "c", "b"}, sorted)
+
+	g.AddEdge("app", "config")
+	_, err = g.TopoSort()
+	assert.ErrorIs(t, err, ErrorCycle)
+	var cycleErr *CycleError[string]
+	assert.True(t, errors.As(err, &cycleErr))
+	assert.Len(t, cycleErr.Cycle, 3)
+	assert.Equal(t, "app", cycleErr.Cycle[0])
+	assert.Equal(t, "config", cycleErr.Cycle[1])
+	for i, n := range cycleErr.Cycle {
+		assert.True(t, g.HasEdge(n, cycleErr.Cycle[(i+1)%len(cycleErr.Cycle)]))
+	}
+	assert.Contains(t, err.Error(), "graph: cycle detected: app -> config -> ")
+
+	self := New[int]()
+	self.AddEdge(1, 2)
+	self.AddEdge(2, 2)
+	_, err = self.TopoSort()
+	assert.Equal(t, "graph: cycle detected: 2 -> 2", err.Error())
+}
+
+func TestGraph_StronglyConnectedComponents(t *testing.T) {
+	g := New[int]()
+	g.AddEdge(1, 2)
+	g.AddEdge(2, 3)
+	g.AddEdge(3, 1)
+	g.AddEdge(3, 4)
+	g.AddEdge(4, 5)
+	g.AddEdge(5, 4)
+	g.AddEdge(5, 6)
+	g.AddNode(7)
+	components := g.StronglyConnectedComponents()
+	for _, c := range components {
+		slices.Sort(c)
+	}
+	assert.Equal(t, [][]int{{6}, {4, 5}, {1, 2, 3}, {7}}, components)
+	assert.Empty(t, New[int]().StronglyConnectedComponents())
+}
+
+func TestGraph_Traversal(t *testing.T) {
+	g := New[int]()
+	g.AddEdge(1, 2)
+	g.AddEdge(1, 3)
+	g.AddEdge(2, 4)
+	g.AddEdge(3, 4)
+	g.AddEdge(4, 1)
+	g.AddEdge(5, 1)
+	assert.Equal(t, []int{1, 2, 3, 4}, slices.Collect(g.BFS(1)))
+	assert.Equal(t, []int{1, 2, 4, 3}, slices.Collect(g.DFS(1)))
+	assert.Equal(t, []int{5, 1, 2, 4, 3}, slices.Collect(g.DFS(5)))
+	assert.Empty(t, slices.Collect(g.BFS(9)))
+	assert.Empty(t, slices.Collect(g.DFS(9)))
+	for n := range g.BFS(1) {
+		assert.Equal(t, 1, n)
+		break
+	}
+}
+
+func TestGraph_ShortestPath(t *testing.T) {
+	g := New[string]()
+	g.AddWeightedEdge("a", "b", 4)
+	g.AddWeightedEdge("a", "c", 1)
+	g.AddWeightedEdge("c", "b", 2)
+	g.AddWeightedEdge("b", "d", 1)
+	g.AddWeightedEdge("c", "d", 5)
+	g.AddNode("e")
+
+	path, dist, ok := g.ShortestPath("a", "d")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"a", "c", "b", "d"}, path)
+	assert.Equal(t, 4.0, dist)
+
+	path, dist, ok = g.ShortestPath("a", "a")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"a"}, path)
+	assert.Equal(t, 0.0, dist)
+
+	_, _, ok = g.ShortestPath("a", "e")
+	assert.False(t, ok)
+	_, _, ok = g.ShortestPath("d", "a")
+	assert.False(t, ok)
+	_, _, ok = g.ShortestPath("x", "a")
+	assert.False(t, ok)
+}
+
+func TestGraph_DOT(t *testing.T) {
+	g := New[string]()
+	g.AddEdge("a", "b")
+	g.AddWeightedEdge("b", `say "hi"`, 0.5)
+	assert.Equal(t, `digraph {
+  "a";
+  "b";
+  "say \"hi\"";
+  "a" -> "b";
+  "b" -> "say \"hi\"" [label="0.5"];
+}
+`, g.DOT())
+}
@@ -0,0 +1,260 @@
+package immutable
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+)
+
+const (
+	branchBits  = 5
+	branchWidth = 1 << branchBits
+	branchMask  = branchWidth - 1
+)
+
+// vnode is a node of the vector trie, leaf nodes hold values and branch nodes hold children.
+type vnode[T any] struct {
+	children []*vnode[T]
+	values   []T
+}
+
+// List is an immutable list implemented by a persistent vector trie with 32-way branching.
+// Operations returning a new List share structure with the original one, so they take O(log32 n) time and space.
+// Get takes O(log32 n) time, and appending to the end is amortized O(1).
+//
+// The zero List is empty and ready for use. A List is safe for concurrent use, as it is never modified.
+type List[T any] struct {
+	size  int
+	shift uint // bits to shift for the index of root children
+	root  *vnode[T]
+	tail  []T // the last 1 to 32 values, not in the trie
+}
+
+// ListOf creates a new List contains the values.
+func ListOf[T any](values ...T) List[T] {
+	return listFromSlice(slices.Clone(values))
+}
+
+// ListFromSeq creates a new List contains the values of seq.
+func ListFromSeq[T any](seq iter.Seq[T]) List[T] {
+	return listFromSlice(slices.Collect(seq))
+}
+
+// listFromSlice builds a List from values in linear time, the values slice is owned by the List.
+func listFromSlice[T any](values []T) List[T] {
+	if len(values) == 0 {
+		return List[T]{}
+	}
+	tailOffset := (len(values) - 1) &^ branchMask
+	l := List[T]{size: len(values), shift: branchBits, tail: values[tailOffset:len(values):len(values)]}
+	var nodes []*vnode[T]
+	for i := 0; i < tailOffset; i += branchWidth {
+		nodes = append(nodes, &vnode[T]{values: values[i : i+branchWidth : i+branchWidth]})
+	}
+	for len(nodes) > branchWidth {
+		var parents []*vnode[T]
+		for i := 0; i < len(nodes); i += branchWidth {
+			parents = append(parents, &vnode[T]{children: nodes[i:min(i+branchWidth, len(nodes)):min(i+branchWidth, len(nodes))]})
+		}
+		nodes = parents
+		l.shift += branchBits
+	}
+	l.root = &vnode[T]{children: nodes}
+	return l
+}
+
+// Size returns the number of values.
+func (l List[T]) Size() int {
+	return l.size
+}
+
+// tailOffset returns the index of the first value in tail.
+func (l List[T]) tailOffset() int {
+	if l.size == 0 {
+		return 0
+	}
+	return (l.size - 1) &^ branchMask
+}
+
+// leaf returns the values of leaf node contains the value at index i.
+func (l List[T]) leaf(i int) []T {
+	if i >= l.tailOffset() {
+		return l.tail
+	}
+	n := l.root
+	for level := l.shift; level > 0; level -= branchBits {
+		n = n.children[(i>>level)&branchMask]
+	}
+	return n.values
+}
+
+// Get returns the value at index i. It panics if i is out of range.
+func (l List[T]) Get(i int) T {
+	l.checkIndex(i)
+	return l.leaf(i)[i&branchMask]
+}
+
+// Set returns a new List with the value at index i replaced. It panics if i is out of range.
+func (l List[T]) Set(i int, v T) List[T] {
+	l.checkIndex(i)
+	if i >= l.tailOffset() {
+		tail := slices.Clone(l.tail)
+		tail[i&branchMask] = v
+		l.tail = tail
+		return l
+	}
+	l.root = setValue(l.root, l.shift, i, v)
+	return l
+}
+
+func setValue[T any](n *vnode[T], level uint, i int, v T) *vnode[T] {
+	if level == 0 {
+		values := slices.Clone(n.values)
+		values[i&branchMask] = v
+		return &vnode[T]{values: values}
+	}
+	children := slices.Clone(n.children)
+	sub := (i >> level) & branchMask
+	children[sub] = setValue(children[sub], level-branchBits, i, v)
+	return &vnode[T]{children: children}
+}
+
+// Append returns a new List with the value added to the end.
+func (l List[T]) Append(v T) List[T] {
+	if l.size == 0 {
+		return List[T]{size: 1, shift: branchBits, root: &vnode[T]{}, tail: []T{v}}
+	}
+	if len(l.tail) < branchWidth {
+		tail := make([]T, len(l.tail)+1)
+		copy(tail, l.tail)
+		tail[len(l.tail)] = v
+		l.tail = tail
+		l.size++
+		return l
+	}
+	leaf := &vnode[T]{values: l.tail}
+	if (l.size >> branchBits) > (1 << l.shift) {
+		// the trie is full, add a new root level
+		l.root = &vnode[T]{children: []*vnode[T]{l.root, newPath(l.shift, leaf)}}
+		l.shift += branchBits
+	} else {
+		l.root = pushLeaf(l.root, l.shift, l.size-1, leaf)
+	}
+	l.tail = []T{v}
+	l.size++
+	return l
+}
+
+// pushLeaf returns a copy of node n with the leaf added, i is the index of the last value in the leaf.
+func pushLeaf[T any](n *vnode[T], level uint, i int, leaf *vnode[T]) *vnode[T] {
+	sub := (i >> level) & branchMask
+	children := slices.Clone(n.children)
+	var child *vnode[T]
+	if level == branchBits {
+		child = leaf
+	} else if sub < len(children) {
+		child = pushLeaf(children[sub], level-branchBits, i, leaf)
+	} else {
+		child = newPath(level-branchBits, leaf)
+	}
+	if sub < len(children) {
+		children[sub] = child
+	} else {
+		children = append(children, child)
+	}
+	return &vnode[T]{children: children}
+}
+
+// newPath returns a path of branch nodes from level to the leaf.
+func newPath[T any](level uint, leaf *vnode[T]) *vnode[T] {
+	if level == 0 {
+		return leaf
+	}
+	return &vnode[T]{children: []*vnode[T]{newPath(level-branchBits, leaf)}}
+}
+
+// Pop returns a new List with the last value removed. It panics if the list is empty.
+func (l List[T]) Pop() List[T] {
+	if l.size == 0 {
+		panic("immutable: pop from empty list")
+	}
+	if l.size == 1 {
+		return List[T]{}
+	}
+	if len(l.tail) > 1 {
+		l.tail = l.tail[: len(l.tail)-1 : len(l.tail)-1]
+		l.size--
+		return l
+	}
+	l.tail = l.leaf(l.size - 2)
+	root := popLeaf(l.root, l.shift, l.size-2)
+	if root == nil {
+		root = &vnode[T]{}
+		l.shift = branchBits
+	}
+	if l.shift > branchBits && len(root.children) == 1 {
+		root = root.children[0]
+		l.shift -= branchBits
+	}
+	l.root = root
+	l.size--
+	return l
+}
+
+// popLeaf returns a copy of node n with the last leaf removed, or nil if the node becomes empty.
+// i is the index of the last value in the removed leaf.
+func popLeaf[T any](n *vnode[T], level uint, i int) *vnode[T] {
+	sub := (i >> level) & branchMask
+	if level > branchBits {
+		child := popLeaf(n.children[sub], level-branchBits, i)
+		if child == nil && sub == 0 {
+			return nil
+		}
+		children := slices.Clone(n.children[:sub+1])
+		if child == nil {
+			children = children[:sub]
+		} else {
+			children[sub] = child
+		}
+		return &vnode[T]{children: children}
+	}
+	if sub == 0 {
+		return nil
+	}
+	return &vnode[T]{children: n.children[:sub:sub]}
+}
+
+// All returns all index-values as a [iter.Seq2].
+func (l List[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := 0; i < l.size; i += branchWidth {
+			for j, v := range l.leaf(i) {
+				if !yield(i+j, v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Values returns all values as a [iter.Seq].
+func (l List[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range l.All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// ToSlice returns a new slice contains all values.
+func (l List[T]) ToSlice() []T {
+	return slices.AppendSeq(make([]T, 0, l.size), l.Values())
+}
+
+func (l List[T]) checkIndex(i int) {
+	if i < 0 || i >= l.size {
+		panic(fmt.Sprintf("immutable: index %d out of range [0, %d)", i, l.size))
+	}
+}
@@ -0,0 +1,105 @@
+package immutable
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestList(t *testing.T) {
+	var l List[int]
+	assert.Equal(t, 0, l.Size())
+	assert.Empty(t, l.ToSlice())
+
+	l1 := l.Append(1).Append(2).Append(3)
+	l2 := l1.Set(1, 20)
+	l3 := l2.Pop()
+	assert.Equal(t, []int{1, 2, 3}, l1.ToSlice())
+	assert.Equal(t, []int{1, 20, 3}, l2.ToSlice())
+	assert.Equal(t, []int{1, 20}, l3.ToSlice())
+	assert.Equal(t, 20, l3.Get(1))
+	assert.Equal(t, 0, l.Size())
+
+	assert.Panics(t, func() { l3.Get(2) })
+	assert.Panics(t, func() { l.Pop() })
+
+	assert.Equal(t, []int{1, 2}, ListOf(1, 2).ToSlice())
+	assert.Equal(t, []int{3, 4}, ListFromSeq(slices.Values([]int{3, 4})).ToSlice())
+}
+
+func TestList_Large(t *testing.T) {
+	for _, n := range []int{31, 32, 33, 1024, 1056, 1057, 40000} {
+		values := make([]int, n)
+		for i := range values {
+			values[i] = i
+		}
+		built := ListOf(values...)
+		var appended List[int]
+		for _, v := range values {
+			appended = appended.Append(v)
+		}
+		assert.Equal(t, values, built.ToSlice())
+		assert.Equal(t, values, appended.ToSlice())
+
+		// pop all and check the list is consistent along the way
+		l := built
+		for i := n - 1; i >= 0; i-- {
+			assert.Equal(t, i, l.Get(i))
+			l = l.Pop()
+			if i%1000 == 0 {
+				assert.Equal(t, values[:i], l.ToSlice())
+			}
+		}
+		assert.Equal(t, 0, l.Size())
+		assert.Equal(t, values, built.ToSlice())
+	}
+}
+
+func TestList_Random(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	var versions []List[int]
+	var models [][]int
+	var l List[int]
+	var model []int
+	for step := 0; step < 20000; step++ {
+		switch op := r.IntN(10); {
+		case op < 6:
+			l = l.Append(step)
+			model = append(slices.Clip(model), step)
+		case op < 8 && len(model) > 0:
+			i := r.IntN(len(model))
+			l = l.Set(i, -step)
+			model = slices.Clone(model)
+			model[i] = -step
+		case len(model) > 0:
+			l = l.Pop()
+			model = slices.Clip(model[:len(model)-1])
+		}
+		if step%500 == 0 {
+			versions = append(versions, l)
+			models = append(models, model)
+		}
+	}
+	assert.Equal(t, model, l.ToSlice())
+	for i, v := range versions {
+		assert.Equal(t, append([]int{}, models[i]...), append([]int{}, v.ToSlice()...))
+	}
+}
+
+func BenchmarkList_Append(b *testing.B) {
+	l := ListOf(make([]int, 10000)...)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = l.Append(i)
+	}
+}
+
+func BenchmarkSlice_CloneAppend(b *testing.B) {
+	s := make([]int, 10000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = append(slices.Clone(s), i)
+	}
+}
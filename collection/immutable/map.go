@@ -0,0 +1,268 @@
+package immutable
+
+import (
+	"hash/maphash"
+	"iter"
+	"math/bits"
+	"slices"
+
+	"github.com/hsiafan/go-utils/lang/optional"
+)
+
+// hashBits is the number of bits in the key hash.
+const hashBits = 64
+
+// seed is the seed for hashing keys, shared by all maps so that maps can be compared and merged.
+var seed = maphash.MakeSeed()
+
+// entry is an entry of a HAMT node, it is either a key-value, or a child node.
+type entry[K comparable, V any] struct {
+	hash  uint64
+	key   K
+	value V
+	child *hnode[K, V]
+}
+
+// hnode is a node of the hash array mapped trie. The entries are indexed by the bitmap, that entries[i]
+// is for the i-th set bit. A node at depth where the hash bits are exhausted is a collision node,
+// it keeps entries with the same hash in a list, and the bitmap is unused.
+type hnode[K comparable, V any] struct {
+	bitmap  uint32
+	entries []entry[K, V]
+}
+
+// Map is an immutable map implemented by a hash array mapped trie (HAMT).
+// Operations returning a new Map share structure with the original one, so they take O(log32 n) time and space.
+// Keys are iterated in unspecified order.
+//
+// The zero Map is empty and ready for use. A Map is safe for concurrent use, as it is never modified.
+type Map[K comparable, V any] struct {
+	root *hnode[K, V]
+	size int
+}
+
+// MapFromSeq creates a new Map contains the key-values of seq. For duplicate keys, the last value wins.
+func MapFromSeq[K comparable, V any](seq iter.Seq2[K, V]) Map[K, V] {
+	var m Map[K, V]
+	for k, v := range seq {
+		m = m.With(k, v)
+	}
+	return m
+}
+
+// Size returns the number of key-values.
+func (m Map[K, V]) Size() int {
+	return m.size
+}
+
+// Get returns the value for key.
+func (m Map[K, V]) Get(k K) optional.Optional[V] {
+	h := maphash.Comparable(seed, k)
+	n := m.root
+	for shift := uint(0); n != nil; shift += branchBits {
+		if shift >= hashBits {
+			for _, e := range n.entries {
+				if e.key == k {
+					return optional.OfValue(e.value)
+				}
+			}
+			break
+		}
+		bit := bitpos(h, shift)
+		if n.bitmap&bit == 0 {
+			break
+		}
+		e := &n.entries[n.index(bit)]
+		if e.child != nil {
+			n = e.child
+			continue
+		}
+		if e.hash == h && e.key == k {
+			return optional.OfValue(e.value)
+		}
+		break
+	}
+	return optional.Empty[V]()
+}
+
+// Contains reports whether the key exists.
+func (m Map[K, V]) Contains(k K) bool {
+	return m.Get(k).IsPresent()
+}
+
+// With returns a new Map with the key set to value.
+func (m Map[K, V]) With(k K, v V) Map[K, V] {
+	root, added := with(m.root, 0, entry[K, V]{hash: maphash.Comparable(seed, k), key: k, value: v})
+	m.root = root
+	if added {
+		m.size++
+	}
+	return m
+}
+
+func with[K comparable, V any](n *hnode[K, V], shift uint, e entry[K, V]) (*hnode[K, V], bool) {
+	if n == nil {
+		return &hnode[K, V]{bitmap: bitpos(e.hash, shift), entries: []entry[K, V]{e}}, true
+	}
+	if shift >= hashBits {
+		i := slices.IndexFunc(n.entries, func(c entry[K, V]) bool { return c.key == e.key })
+		if i < 0 {
+			return &hnode[K, V]{entries: append(slices.Clip(n.entries), e)}, true
+		}
+		return n.replace(i, e), false
+	}
+	bit := bitpos(e.hash, shift)
+	i := n.index(bit)
+	if n.bitmap&bit == 0 {
+		return &hnode[K, V]{bitmap: n.bitmap | bit, entries: slices.Insert(slices.Clone(n.entries), i, e)}, true
+	}
+	old := n.entries[i]
+	if old.child != nil {
+		child, added := with(old.child, shift+branchBits, e)
+		return n.replace(i, entry[K, V]{child: child}), added
+	}
+	if old.hash == e.hash && old.key == e.key {
+		return n.replace(i, e), false
+	}
+	return n.replace(i, entry[K, V]{child: merge(shift+branchBits, old, e)}), true
+}
+
+// merge creates a node contains two entries with different keys.
+func merge[K comparable, V any](shift uint, e1, e2 entry[K, V]) *hnode[K, V] {
+	if shift >= hashBits {
+		return &hnode[K, V]{entries: []entry[K, V]{e1, e2}}
+	}
+	b1, b2 := bitpos(e1.hash, shift), bitpos(e2.hash, shift)
+	if b1 == b2 {
+		return &hnode[K, V]{bitmap: b1, entries: []entry[K, V]{{child: merge(shift+branchBits, e1, e2)}}}
+	}
+	if b1 > b2 {
+		e1, e2 = e2, e1
+	}
+	return &hnode[K, V]{bitmap: b1 | b2, entries: []entry[K, V]{e1, e2}}
+}
+
+// Without returns a new Map with the key removed. It returns the same Map if the key not exists.
+func (m Map[K, V]) Without(k K) Map[K, V] {
+	root, removed := without(m.root, 0, maphash.Comparable(seed, k), k)
+	if removed {
+		m.root = root
+		m.size--
+	}
+	return m
+}
+
+// without returns a new node with the key removed, or nil if the node becomes empty.
+func without[K comparable, V any](n *hnode[K, V], shift uint, h uint64, k K) (*hnode[K, V], bool) {
+	if n == nil {
+		return nil, false
+	}
+	if shift >= hashBits {
+		i := slices.IndexFunc(n.entries, func(c entry[K, V]) bool { return c.key == k })
+		if i < 0 {
+			return n, false
+		}
+		return n.remove(i, 0), true
+	}
+	bit := bitpos(h, shift)
+	if n.bitmap&bit == 0 {
+		return n, false
+	}
+	i := n.index(bit)
+	e := n.entries[i]
+	if e.child == nil {
+		if e.hash != h || e.key != k {
+			return n, false
+		}
+		return n.remove(i, bit), true
+	}
+	child, removed := without(e.child, shift+branchBits, h, k)
+	if !removed {
+		return n, false
+	}
+	if child == nil {
+		return n.remove(i, bit), true
+	}
+	if len(child.entries) == 1 && child.entries[0].child == nil {
+		// collapse the child with single key-value into this node
+		return n.replace(i, child.entries[0]), true
+	}
+	return n.replace(i, entry[K, V]{child: child}), true
+}
+
+// replace returns a copy of the node with the i-th entry replaced.
+func (n *hnode[K, V]) replace(i int, e entry[K, V]) *hnode[K, V] {
+	entries := slices.Clone(n.entries)
+	entries[i] = e
+	return &hnode[K, V]{bitmap: n.bitmap, entries: entries}
+}
+
+// remove returns a copy of the node with the i-th entry removed, or nil if the node becomes empty.
+func (n *hnode[K, V]) remove(i int, bit uint32) *hnode[K, V] {
+	if len(n.entries) == 1 {
+		return nil
+	}
+	entries := make([]entry[K, V], 0, len(n.entries)-1)
+	entries = append(append(entries, n.entries[:i]...), n.entries[i+1:]...)
+	return &hnode[K, V]{bitmap: n.bitmap &^ bit, entries: entries}
+}
+
+// index returns the index in entries of the bit.
+func (n *hnode[K, V]) index(bit uint32) int {
+	return bits.OnesCount32(n.bitmap & (bit - 1))
+}
+
+// bitpos returns the bitmap bit for the hash at shift.
+func bitpos(h uint64, shift uint) uint32 {
+	return 1 << ((h >> shift) & branchMask)
+}
+
+// All returns all key-values as a [iter.Seq2], in unspecified order.
+func (m Map[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		walk(m.root, yield)
+	}
+}
+
+func walk[K comparable, V any](n *hnode[K, V], yield func(K, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	for _, e := range n.entries {
+		if e.child != nil {
+			if !walk(e.child, yield) {
+				return false
+			}
+		} else if !yield(e.key, e.value) {
+			return false
+		}
+	}
+	return true
+}
+
+// Keys returns all keys as a [iter.Seq], in unspecified order.
+func (m Map[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		walk(m.root, func(k K, _ V) bool {
+			return yield(k)
+		})
+	}
+}
+
+// Values returns all values as a [iter.Seq], in unspecified order.
+func (m Map[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		walk(m.root, func(_ K, v V) bool {
+			return yield(v)
+		})
+	}
+}
+
+// ToMap returns a new builtin map contains all key-values.
+func (m Map[K, V]) ToMap() map[K]V {
+	r := make(map[K]V, m.size)
+	for k, v := range m.All() {
+		r[k] = v
+	}
+	return r
+}
@@ -0,0 +1,119 @@
+package immutable
+
+import (
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/hsiafan/go-utils/collection/linkedmap"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMap(t *testing.T) {
+	var m Map[string, int]
+	assert.Equal(t, 0, m.Size())
+	assert.True(t, m.Get("a").IsEmpty())
+
+	m1 := m.With("a", 1).With("b", 2)
+	m2 := m1.With("a", 10).With("c", 3)
+	m3 := m2.Without("b").Without("x")
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, m1.ToMap())
+	assert.Equal(t, map[string]int{"a": 10, "b": 2, "c": 3}, m2.ToMap())
+	assert.Equal(t, map[string]int{"a": 10, "c": 3}, m3.ToMap())
+	assert.Equal(t, 2, m3.Size())
+	assert.Equal(t, 10, m3.Get("a").Get())
+	assert.False(t, m3.Contains("b"))
+	assert.ElementsMatch(t, []string{"a", "c"}, slices.Collect(m3.Keys()))
+	assert.ElementsMatch(t, []int{10, 3}, slices.Collect(m3.Values()))
+
+	m4 := MapFromSeq(maps.All(map[string]int{"x": 1, "y": 2}))
+	assert.Equal(t, map[string]int{"x": 1, "y": 2}, m4.ToMap())
+	assert.Equal(t, 0, m4.Without("x").Without("y").Size())
+}
+
+func TestMap_Random(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	var m Map[int, int]
+	model := map[int]int{}
+	var versions []Map[int, int]
+	var models []map[int]int
+	for step := 0; step < 20000; step++ {
+		k := r.IntN(2000)
+		if r.IntN(3) == 0 {
+			m = m.Without(k)
+			delete(model, k)
+		} else {
+			m = m.With(k, step)
+			model[k] = step
+		}
+		assert.Equal(t, len(model), m.Size())
+		if step%1000 == 0 {
+			versions = append(versions, m)
+			models = append(models, maps.Clone(model))
+		}
+	}
+	assert.Equal(t, model, m.ToMap())
+	for i, v := range versions {
+		assert.Equal(t, models[i], v.ToMap())
+	}
+}
+
+func TestMap_Collision(t *testing.T) {
+	// all keys have the same hash, so they are kept in a collision node
+	var root *hnode[string, int]
+	for i := 0; i < 5; i++ {
+		var added bool
+		root, added = with(root, 0, entry[string, int]{hash: 42, key: strconv.Itoa(i), value: i})
+		assert.True(t, added)
+	}
+	m := Map[string, int]{root: root, size: 5}
+	assert.Equal(t, map[string]int{"0": 0, "1": 1, "2": 2, "3": 3, "4": 4}, m.ToMap())
+
+	root, added := with(root, 0, entry[string, int]{hash: 42, key: "2", value: 20})
+	assert.False(t, added)
+	for i := 0; i < 5; i++ {
+		var removed bool
+		root, removed = without(root, 0, 42, strconv.Itoa(i))
+		assert.True(t, removed)
+		_, removed = without(root, 0, 42, strconv.Itoa(i))
+		assert.False(t, removed)
+		if i == 3 {
+			// the single remained key-value is collapsed into root
+			assert.Nil(t, root.entries[0].child)
+			assert.Equal(t, "4", root.entries[0].key)
+		}
+	}
+	assert.Nil(t, root)
+}
+
+func benchmarkKeys() []string {
+	keys := make([]string, 10000)
+	for i := range keys {
+		keys[i] = strconv.Itoa(i)
+	}
+	return keys
+}
+
+func BenchmarkMap_With(b *testing.B) {
+	var m Map[string, int]
+	for i, k := range benchmarkKeys() {
+		m = m.With(k, i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = m.With("new", i)
+	}
+}
+
+func BenchmarkLinkedMap_CopyPut(b *testing.B) {
+	m := linkedmap.New[string, int]()
+	for i, k := range benchmarkKeys() {
+		m.Put(k, i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Copy().Put("new", i)
+	}
+}
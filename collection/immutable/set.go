@@ -0,0 +1,67 @@
+package immutable
+
+import (
+	"iter"
+	"slices"
+
+	"github.com/hsiafan/go-utils/collection"
+)
+
+type empty struct{}
+
+// Set is an immutable set implemented by [Map]. Operations returning a new Set share structure
+// with the original one, so they take O(log32 n) time and space. Elements are iterated in unspecified order.
+//
+// The zero Set is empty and ready for use. A Set is safe for concurrent use, as it is never modified.
+type Set[T comparable] struct {
+	m Map[T, empty]
+}
+
+var _ collection.SetView[int] = Set[int]{}
+
+// SetOf creates a new Set contains the values.
+func SetOf[T comparable](values ...T) Set[T] {
+	return SetFromSeq(slices.Values(values))
+}
+
+// SetFromSeq creates a new Set contains the values of seq.
+func SetFromSeq[T comparable](seq iter.Seq[T]) Set[T] {
+	var s Set[T]
+	for v := range seq {
+		s = s.With(v)
+	}
+	return s
+}
+
+// Contains reports whether the value is in the set.
+func (s Set[T]) Contains(v T) bool {
+	return s.m.Contains(v)
+}
+
+// With returns a new Set with the value added.
+func (s Set[T]) With(v T) Set[T] {
+	if s.m.Contains(v) {
+		return s
+	}
+	return Set[T]{s.m.With(v, empty{})}
+}
+
+// Without returns a new Set with the value removed. It returns the same Set if the value not exists.
+func (s Set[T]) Without(v T) Set[T] {
+	return Set[T]{s.m.Without(v)}
+}
+
+// All returns all elements as a [iter.Seq], in unspecified order.
+func (s Set[T]) All() iter.Seq[T] {
+	return s.m.Keys()
+}
+
+// ToSlice returns a new slice contains all elements, in unspecified order.
+func (s Set[T]) ToSlice() []T {
+	return slices.AppendSeq(make([]T, 0, s.Size()), s.All())
+}
+
+// Size returns the number of elements.
+func (s Set[T]) Size() int {
+	return s.m.Size()
+}
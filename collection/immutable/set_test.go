@@ -0,0 +1,47 @@
+package immutable
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/hsiafan/go-utils/collection"
+	"github.com/hsiafan/go-utils/collection/set"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSet(t *testing.T) {
+	var s Set[int]
+	s1 := s.With(1).With(2).With(2)
+	s2 := s1.Without(1).With(3)
+	assert.Equal(t, 0, s.Size())
+	assert.ElementsMatch(t, []int{1, 2}, s1.ToSlice())
+	assert.ElementsMatch(t, []int{2, 3}, s2.ToSlice())
+	assert.True(t, s2.Contains(3))
+	assert.False(t, s2.Contains(1))
+	assert.Equal(t, s2, s2.Without(1))
+
+	// interoperates with other sets
+	other := set.New(3, 4)
+	assert.ElementsMatch(t, []int{2, 3, 4}, slices.Collect(collection.Union[int](s2, other)))
+	assert.True(t, collection.Equal[int](SetFromSeq(other.All()), other))
+	assert.ElementsMatch(t, []int{5, 6}, SetOf(5, 6, 5).ToSlice())
+}
+
+func BenchmarkSet_With(b *testing.B) {
+	var s Set[string]
+	for _, k := range benchmarkKeys() {
+		s = s.With(k)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = s.With("new")
+	}
+}
+
+func BenchmarkSet_CopyAdd(b *testing.B) {
+	s := set.New(benchmarkKeys()...)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Copy().Add("new")
+	}
+}
@@ -0,0 +1,235 @@
+package intervals
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+
+	"github.com/hsiafan/go-utils/lang/optional"
+)
+
+// inode is an AVL tree node, augmented with the max end of intervals in the subtree.
+type inode[T cmp.Ordered, V any] struct {
+	r      Range[T]
+	v      V
+	maxEnd T
+	left   *inode[T, V]
+	right  *inode[T, V]
+	height int
+}
+
+// IntervalTree is a map from non-empty half-open ranges to values, supports finding intervals that contain a point
+// (stabbing query) or overlap a range. It is implemented by an AVL tree ordered by range start then end,
+// and augmented with the max end of each subtree. Put, Get and Delete take O(log n) time,
+// and queries take O(log n + k) time, where k is the number of intervals found.
+//
+// The zero IntervalTree is empty and ready for use. The IntervalTree should not be modified during iteration.
+// Stabbing and overlap queries do not change an IntervalTree, so they may run concurrently, but not with Put or Delete.
+type IntervalTree[T cmp.Ordered, V any] struct {
+	root *inode[T, V]
+	size int
+}
+
+// NewIntervalTree creates a new empty IntervalTree.
+func NewIntervalTree[T cmp.Ordered, V any]() *IntervalTree[T, V] {
+	return &IntervalTree[T, V]{}
+}
+
+// Put adds or sets value for the interval. It panics if the interval is empty.
+func (t *IntervalTree[T, V]) Put(r Range[T], v V) {
+	if r.IsEmpty() {
+		panic(fmt.Sprintf("intervals: empty interval %v", r))
+	}
+	t.root = t.put(t.root, r, v)
+}
+
+func (t *IntervalTree[T, V]) put(n *inode[T, V], r Range[T], v V) *inode[T, V] {
+	if n == nil {
+		t.size++
+		return &inode[T, V]{r: r, v: v, maxEnd: r.End, height: 1}
+	}
+	switch c := r.compare(n.r); {
+	case c < 0:
+		n.left = t.put(n.left, r, v)
+	case c > 0:
+		n.right = t.put(n.right, r, v)
+	default:
+		n.v = v
+		return n
+	}
+	return rebalance(n)
+}
+
+// Get returns the value for the interval.
+func (t *IntervalTree[T, V]) Get(r Range[T]) optional.Optional[V] {
+	n := t.root
+	for n != nil {
+		switch c := r.compare(n.r); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return optional.OfValue(n.v)
+		}
+	}
+	return optional.Empty[V]()
+}
+
+// Delete removes the interval, returns true if the interval existed.
+func (t *IntervalTree[T, V]) Delete(r Range[T]) bool {
+	size := t.size
+	t.root = t.delete(t.root, r)
+	return t.size < size
+}
+
+func (t *IntervalTree[T, V]) delete(n *inode[T, V], r Range[T]) *inode[T, V] {
+	if n == nil {
+		return nil
+	}
+	switch c := r.compare(n.r); {
+	case c < 0:
+		n.left = t.delete(n.left, r)
+	case c > 0:
+		n.right = t.delete(n.right, r)
+	default:
+		t.size--
+		if n.left == nil {
+			return n.right
+		}
+		if n.right == nil {
+			return n.left
+		}
+		var successor *inode[T, V]
+		n.right, successor = removeMin(n.right)
+		successor.left, successor.right = n.left, n.right
+		n = successor
+	}
+	return rebalance(n)
+}
+
+// removeMin removes the least node of the subtree, returns the new subtree and the removed node.
+func removeMin[T cmp.Ordered, V any](n *inode[T, V]) (*inode[T, V], *inode[T, V]) {
+	if n.left == nil {
+		return n.right, n
+	}
+	var least *inode[T, V]
+	n.left, least = removeMin(n.left)
+	return rebalance(n), least
+}
+
+// Stab returns the intervals contain the point as a [iter.Seq2], ordered by start then end.
+func (t *IntervalTree[T, V]) Stab(p T) iter.Seq2[Range[T], V] {
+	return func(yield func(Range[T], V) bool) {
+		query(t.root, p, func(start T) bool { return start > p }, func(r Range[T]) bool { return r.Contains(p) }, yield)
+	}
+}
+
+// Overlapping returns the intervals overlap the range as a [iter.Seq2], ordered by start then end.
+func (t *IntervalTree[T, V]) Overlapping(q Range[T]) iter.Seq2[Range[T], V] {
+	return func(yield func(Range[T], V) bool) {
+		if q.IsEmpty() {
+			return
+		}
+		query(t.root, q.Start, func(start T) bool { return start >= q.End }, q.Overlaps, yield)
+	}
+}
+
+// query visits intervals matched in order. Subtrees with max end <= low are skipped, as no interval there
+// can match; and nodes after the one whose start is beyond the query are skipped.
+func query[T cmp.Ordered, V any](n *inode[T, V], low T, beyond func(start T) bool, match func(Range[T]) bool,
+	yield func(Range[T], V) bool) bool {
+	if n == nil || n.maxEnd <= low {
+		return true
+	}
+	if !query(n.left, low, beyond, match, yield) {
+		return false
+	}
+	if beyond(n.r.Start) {
+		return true
+	}
+	if match(n.r) && !yield(n.r, n.v) {
+		return false
+	}
+	return query(n.right, low, beyond, match, yield)
+}
+
+// All returns all intervals as a [iter.Seq2], ordered by start then end.
+func (t *IntervalTree[T, V]) All() iter.Seq2[Range[T], V] {
+	return func(yield func(Range[T], V) bool) {
+		walk(t.root, yield)
+	}
+}
+
+func walk[T cmp.Ordered, V any](n *inode[T, V], yield func(Range[T], V) bool) bool {
+	if n == nil {
+		return true
+	}
+	return walk(n.left, yield) && yield(n.r, n.v) && walk(n.right, yield)
+}
+
+// Size returns the number of intervals.
+func (t *IntervalTree[T, V]) Size() int {
+	return t.size
+}
+
+// Clear removes all intervals.
+func (t *IntervalTree[T, V]) Clear() {
+	t.root = nil
+	t.size = 0
+}
+
+func height[T cmp.Ordered, V any](n *inode[T, V]) int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+// update recomputes height and max end of the node from its children.
+func update[T cmp.Ordered, V any](n *inode[T, V]) {
+	n.height = max(height(n.left), height(n.right)) + 1
+	n.maxEnd = n.r.End
+	if n.left != nil {
+		n.maxEnd = max(n.maxEnd, n.left.maxEnd)
+	}
+	if n.right != nil {
+		n.maxEnd = max(n.maxEnd, n.right.maxEnd)
+	}
+}
+
+func rotateLeft[T cmp.Ordered, V any](n *inode[T, V]) *inode[T, V] {
+	r := n.right
+	n.right = r.left
+	r.left = n
+	update(n)
+	update(r)
+	return r
+}
+
+func rotateRight[T cmp.Ordered, V any](n *inode[T, V]) *inode[T, V] {
+	l := n.left
+	n.left = l.right
+	l.right = n
+	update(n)
+	update(l)
+	return l
+}
+
+// rebalance updates the node and restores the AVL balance, returns the new subtree root.
+func rebalance[T cmp.Ordered, V any](n *inode[T, V]) *inode[T, V] {
+	update(n)
+	switch balance := height(n.left) - height(n.right); {
+	case balance > 1:
+		if height(n.left.left) < height(n.left.right) {
+			n.left = rotateLeft(n.left)
+		}
+		return rotateRight(n)
+	case balance < -1:
+		if height(n.right.right) < height(n.right.left) {
+			n.right = rotateRight(n.right)
+		}
+		return rotateLeft(n)
+	}
+	return n
+}
@@ -0,0 +1,109 @@
+package intervals
+
+import (
+	"iter"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntervalTree(t *testing.T) {
+	var tree IntervalTree[int, string]
+	tree.Put(Of(1, 5), "a")
+	tree.Put(Of(3, 8), "b")
+	tree.Put(Of(6, 9), "c")
+	tree.Put(Of(1, 2), "d")
+	tree.Put(Of(1, 5), "A")
+	assert.Equal(t, 4, tree.Size())
+	assert.Equal(t, "A", tree.Get(Of(1, 5)).Get())
+	assert.True(t, tree.Get(Of(1, 6)).IsEmpty())
+
+	assert.Equal(t, []Range[int]{{1, 5}, {3, 8}}, slices.Collect(keys(tree.Stab(4))))
+	assert.Equal(t, []Range[int]{{3, 8}, {6, 9}}, slices.Collect(keys(tree.Stab(6))))
+	assert.Empty(t, slices.Collect(keys(tree.Stab(9))))
+	assert.Equal(t, []Range[int]{{1, 2}, {1, 5}}, slices.Collect(keys(tree.Overlapping(Of(0, 3)))))
+	assert.Empty(t, slices.Collect(keys(tree.Overlapping(Of(4, 4)))))
+	assert.Equal(t, []Range[int]{{1, 2}, {1, 5}, {3, 8}, {6, 9}}, slices.Collect(keys(tree.All())))
+
+	assert.True(t, tree.Delete(Of(3, 8)))
+	assert.False(t, tree.Delete(Of(3, 8)))
+	assert.Empty(t, slices.Collect(keys(tree.Stab(5))))
+	assert.Panics(t, func() { tree.Put(Of(3, 3), "e") })
+
+	tree.Clear()
+	assert.Equal(t, 0, tree.Size())
+}
+
+func keys[K, V any](seq iter.Seq2[K, V]) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range seq {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+func TestIntervalTree_Random(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	tree := NewIntervalTree[int, int]()
+	model := map[Range[int]]int{}
+	for step := 0; step < 3000; step++ {
+		a, b := r.IntN(200), r.IntN(200)
+		if a == b {
+			continue
+		}
+		rg := Of(min(a, b), max(a, b))
+		if r.IntN(3) == 0 {
+			_, ok := model[rg]
+			delete(model, rg)
+			assert.Equal(t, ok, tree.Delete(rg))
+		} else {
+			model[rg] = step
+			tree.Put(rg, step)
+		}
+		assert.Equal(t, len(model), tree.Size())
+		checkNode(t, tree.root)
+
+		p := r.IntN(200)
+		var expected []Range[int]
+		for rg := range model {
+			if rg.Contains(p) {
+				expected = append(expected, rg)
+			}
+		}
+		slices.SortFunc(expected, Range[int].compare)
+		assert.Equal(t, expected, slices.Collect(keys(tree.Stab(p))))
+
+		q := Of(p, p+r.IntN(20))
+		expected = nil
+		for rg := range model {
+			if rg.Overlaps(q) {
+				expected = append(expected, rg)
+			}
+		}
+		slices.SortFunc(expected, Range[int].compare)
+		assert.Equal(t, expected, slices.Collect(keys(tree.Overlapping(q))))
+	}
+}
+
+// checkNode checks the AVL balance and the max end of the subtree, returns the height.
+func checkNode[V any](t *testing.T, n *inode[int, V]) int {
+	if n == nil {
+		return 0
+	}
+	lh, rh := checkNode(t, n.left), checkNode(t, n.right)
+	assert.LessOrEqual(t, max(lh-rh, rh-lh), 1)
+	assert.Equal(t, max(lh, rh)+1, n.height)
+	maxEnd := n.r.End
+	if n.left != nil {
+		maxEnd = max(maxEnd, n.left.maxEnd)
+	}
+	if n.right != nil {
+		maxEnd = max(maxEnd, n.right.maxEnd)
+	}
+	assert.Equal(t, maxEnd, n.maxEnd)
+	return n.height
+}
@@ -0,0 +1,50 @@
+package intervals
+
+import (
+	"cmp"
+	"fmt"
+)
+
+// Range is a half-open interval [Start, End). A Range with Start >= End is empty.
+type Range[T cmp.Ordered] struct {
+	Start T
+	End   T
+}
+
+// Of creates a new Range [start, end).
+func Of[T cmp.Ordered](start, end T) Range[T] {
+	return Range[T]{Start: start, End: end}
+}
+
+// IsEmpty reports whether the range contains no value.
+func (r Range[T]) IsEmpty() bool {
+	return r.Start >= r.End
+}
+
+// Contains reports whether the value is in the range.
+func (r Range[T]) Contains(v T) bool {
+	return r.Start <= v && v < r.End
+}
+
+// Overlaps reports whether the two ranges have common values.
+func (r Range[T]) Overlaps(other Range[T]) bool {
+	return r.Start < other.End && other.Start < r.End && !r.IsEmpty() && !other.IsEmpty()
+}
+
+// Intersection returns the common part of the two ranges, it may be empty.
+func (r Range[T]) Intersection(other Range[T]) Range[T] {
+	return Range[T]{Start: max(r.Start, other.Start), End: min(r.End, other.End)}
+}
+
+// String returns the range in form of [start, end).
+func (r Range[T]) String() string {
+	return fmt.Sprintf("[%v, %v)", r.Start, r.End)
+}
+
+// compare compares ranges by start, then by end.
+func (r Range[T]) compare(other Range[T]) int {
+	if c := cmp.Compare(r.Start, other.Start); c != 0 {
+		return c
+	}
+	return cmp.Compare(r.End, other.End)
+}
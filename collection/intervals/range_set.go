@@ -0,0 +1,152 @@
+package intervals
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+	"sort"
+)
+
+// RangeSet is a set of values represented by disjoint half-open ranges. Overlapping or adjacent ranges are merged
+// when added, so the set keeps the least number of ranges, sorted by start.
+// The ranges are kept in a sorted slice: queries take O(log n) time, while Add and Remove take O(n) time,
+// as they shift the ranges after the affected ones.
+//
+// The zero RangeSet is empty and ready for use.
+// A RangeSet is not safe for concurrent use; Add and Remove replace the ranges in place.
+type RangeSet[T cmp.Ordered] struct {
+	ranges []Range[T]
+}
+
+// NewRangeSet creates a new RangeSet contains the ranges.
+func NewRangeSet[T cmp.Ordered](ranges ...Range[T]) *RangeSet[T] {
+	s := &RangeSet[T]{}
+	for _, r := range ranges {
+		s.Add(r)
+	}
+	return s
+}
+
+// search returns the index of the first range satisfies pred, which should be monotonic over the sorted ranges.
+func (s *RangeSet[T]) search(pred func(r Range[T]) bool) int {
+	return sort.Search(len(s.ranges), func(i int) bool { return pred(s.ranges[i]) })
+}
+
+// Add adds all values in the range. Adding an empty range does nothing.
+func (s *RangeSet[T]) Add(r Range[T]) {
+	if r.IsEmpty() {
+		return
+	}
+	// ranges in [i, j) overlap with or are adjacent to r
+	i := s.search(func(c Range[T]) bool { return c.End >= r.Start })
+	j := s.search(func(c Range[T]) bool { return c.Start > r.End })
+	if i < j {
+		r.Start = min(r.Start, s.ranges[i].Start)
+		r.End = max(r.End, s.ranges[j-1].End)
+	}
+	s.ranges = slices.Replace(s.ranges, i, j, r)
+}
+
+// Remove removes all values in the range.
+func (s *RangeSet[T]) Remove(r Range[T]) {
+	if r.IsEmpty() {
+		return
+	}
+	// ranges in [i, j) overlap with r
+	i := s.search(func(c Range[T]) bool { return c.End > r.Start })
+	j := s.search(func(c Range[T]) bool { return c.Start >= r.End })
+	if i >= j {
+		return
+	}
+	var remained []Range[T]
+	if first := s.ranges[i]; first.Start < r.Start {
+		remained = append(remained, Range[T]{Start: first.Start, End: r.Start})
+	}
+	if last := s.ranges[j-1]; last.End > r.End {
+		remained = append(remained, Range[T]{Start: r.End, End: last.End})
+	}
+	s.ranges = slices.Replace(s.ranges, i, j, remained...)
+}
+
+// Contains reports whether the value is in the set.
+func (s *RangeSet[T]) Contains(v T) bool {
+	i := s.search(func(c Range[T]) bool { return c.End > v })
+	return i < len(s.ranges) && s.ranges[i].Start <= v
+}
+
+// Encloses reports whether all values of the range are in the set. An empty range is always enclosed.
+func (s *RangeSet[T]) Encloses(r Range[T]) bool {
+	if r.IsEmpty() {
+		return true
+	}
+	i := s.search(func(c Range[T]) bool { return c.End >= r.End })
+	return i < len(s.ranges) && s.ranges[i].Start <= r.Start
+}
+
+// Overlaps reports whether any value of the range is in the set.
+func (s *RangeSet[T]) Overlaps(r Range[T]) bool {
+	if r.IsEmpty() {
+		return false
+	}
+	i := s.search(func(c Range[T]) bool { return c.End > r.Start })
+	return i < len(s.ranges) && s.ranges[i].Start < r.End
+}
+
+// Complement returns a new RangeSet contains the values within bounds that are not in this set.
+func (s *RangeSet[T]) Complement(bounds Range[T]) *RangeSet[T] {
+	result := &RangeSet[T]{}
+	if bounds.IsEmpty() {
+		return result
+	}
+	start := bounds.Start
+	i := s.search(func(c Range[T]) bool { return c.End > bounds.Start })
+	for ; i < len(s.ranges) && s.ranges[i].Start < bounds.End; i++ {
+		if s.ranges[i].Start > start {
+			result.ranges = append(result.ranges, Range[T]{Start: start, End: s.ranges[i].Start})
+		}
+		start = s.ranges[i].End
+	}
+	if start < bounds.End {
+		result.ranges = append(result.ranges, Range[T]{Start: start, End: bounds.End})
+	}
+	return result
+}
+
+// All returns the disjoint ranges as a [iter.Seq], sorted by start.
+func (s *RangeSet[T]) All() iter.Seq[Range[T]] {
+	return func(yield func(Range[T]) bool) {
+		for _, r := range s.ranges {
+			if !yield(r) {
+				break
+			}
+		}
+	}
+}
+
+// Span returns the least range encloses all values in the set, or an empty Range if the set is empty.
+func (s *RangeSet[T]) Span() Range[T] {
+	if len(s.ranges) == 0 {
+		return Range[T]{}
+	}
+	return Range[T]{Start: s.ranges[0].Start, End: s.ranges[len(s.ranges)-1].End}
+}
+
+// Size returns the number of disjoint ranges.
+func (s *RangeSet[T]) Size() int {
+	return len(s.ranges)
+}
+
+// IsEmpty reports whether the set contains no value.
+func (s *RangeSet[T]) IsEmpty() bool {
+	return len(s.ranges) == 0
+}
+
+// Copy returns a new RangeSet with the same ranges.
+func (s *RangeSet[T]) Copy() *RangeSet[T] {
+	return &RangeSet[T]{ranges: slices.Clone(s.ranges)}
+}
+
+// Clear removes all values.
+func (s *RangeSet[T]) Clear() {
+	s.ranges = s.ranges[:0]
+}
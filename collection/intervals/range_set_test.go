@@ -0,0 +1,114 @@
+package intervals
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRange(t *testing.T) {
+	r := Of(1, 5)
+	assert.False(t, r.IsEmpty())
+	assert.True(t, Of(3, 3).IsEmpty())
+	assert.True(t, r.Contains(1))
+	assert.False(t, r.Contains(5))
+	assert.True(t, r.Overlaps(Of(4, 8)))
+	assert.False(t, r.Overlaps(Of(5, 8)))
+	assert.False(t, r.Overlaps(Of(3, 3)))
+	assert.Equal(t, Of(4, 5), r.Intersection(Of(4, 8)))
+	assert.True(t, r.Intersection(Of(6, 8)).IsEmpty())
+	assert.Equal(t, "[1, 5)", r.String())
+}
+
+func TestRangeSet(t *testing.T) {
+	var s RangeSet[int]
+	s.Add(Of(1, 3))
+	s.Add(Of(5, 7))
+	s.Add(Of(3, 4)) // adjacent, merged
+	s.Add(Of(9, 9)) // empty, ignored
+	assert.Equal(t, []Range[int]{{1, 4}, {5, 7}}, slices.Collect(s.All()))
+
+	s.Add(Of(0, 6))
+	assert.Equal(t, []Range[int]{{0, 7}}, slices.Collect(s.All()))
+	s.Remove(Of(2, 3))
+	s.Remove(Of(6, 10))
+	assert.Equal(t, []Range[int]{{0, 2}, {3, 6}}, slices.Collect(s.All()))
+	assert.Equal(t, 2, s.Size())
+	assert.Equal(t, Of(0, 6), s.Span())
+
+	assert.True(t, s.Contains(0))
+	assert.False(t, s.Contains(2))
+	assert.True(t, s.Contains(5))
+	assert.False(t, s.Contains(6))
+	assert.True(t, s.Overlaps(Of(1, 4)))
+	assert.False(t, s.Overlaps(Of(2, 3)))
+	assert.True(t, s.Encloses(Of(3, 6)))
+	assert.False(t, s.Encloses(Of(1, 4)))
+
+	assert.Equal(t, []Range[int]{{-5, 0}, {2, 3}, {6, 10}}, slices.Collect(s.Complement(Of(-5, 10)).All()))
+	assert.Equal(t, []Range[int]{{2, 3}}, slices.Collect(s.Complement(Of(1, 4)).All()))
+	assert.True(t, s.Complement(Of(3, 5)).IsEmpty())
+
+	c := s.Copy()
+	s.Clear()
+	assert.True(t, s.IsEmpty())
+	assert.Equal(t, 2, c.Size())
+}
+
+func TestRangeSet_TimeWindows(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+	hour := int64(time.Hour / time.Second)
+	windows := NewRangeSet(Of(base+2*hour, base+4*hour), Of(base+3*hour, base+5*hour))
+	assert.Equal(t, []Range[int64]{{base + 2*hour, base + 5*hour}}, slices.Collect(windows.All()))
+	assert.False(t, windows.Overlaps(Of(base+5*hour, base+6*hour)))
+}
+
+func TestRangeSet_Random(t *testing.T) {
+	const n = 100
+	r := rand.New(rand.NewPCG(1, 2))
+	var s RangeSet[int]
+	var model [n]bool
+	for step := 0; step < 2000; step++ {
+		a, b := r.IntN(n), r.IntN(n)
+		rg := Of(min(a, b), max(a, b))
+		if r.IntN(2) == 0 {
+			s.Add(rg)
+			for i := rg.Start; i < rg.End; i++ {
+				model[i] = true
+			}
+		} else {
+			s.Remove(rg)
+			for i := rg.Start; i < rg.End; i++ {
+				model[i] = false
+			}
+		}
+
+		// ranges are disjoint, non-adjacent, and sorted
+		ranges := slices.Collect(s.All())
+		for i, rg := range ranges {
+			assert.False(t, rg.IsEmpty())
+			if i > 0 {
+				assert.Less(t, ranges[i-1].End, rg.Start)
+			}
+		}
+		for i := 0; i < n; i++ {
+			assert.Equal(t, model[i], s.Contains(i))
+		}
+		c, d := r.IntN(n), r.IntN(n)
+		q := Of(min(c, d), max(c, d))
+		overlaps, encloses := false, true
+		for i := q.Start; i < q.End; i++ {
+			overlaps = overlaps || model[i]
+			encloses = encloses && model[i]
+		}
+		assert.Equal(t, overlaps, s.Overlaps(q))
+		assert.Equal(t, encloses, s.Encloses(q))
+		complement := s.Complement(q)
+		for i := 0; i < n; i++ {
+			assert.Equal(t, q.Contains(i) && !model[i], complement.Contains(i))
+		}
+	}
+}
@@ -1,9 +1,14 @@
 package iters
 
 import (
+	"cmp"
 	"iter"
+	"strings"
 
 	"github.com/hsiafan/go-utils/collection/pair"
+	"github.com/hsiafan/go-utils/lang/optional"
+	"github.com/hsiafan/go-utils/math/floats"
+	"github.com/hsiafan/go-utils/math/ints"
 )
 
 // Map maps to a new Seq with values applied func convert
@@ -152,3 +157,331 @@ func Indexed[T any](seq iter.Seq[T]) iter.Seq2[int, T] {
 		}
 	}
 }
+
+// Concat returns a sequence containing all elements of seqs, one after another.
+func Concat[T any](seqs ...iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, seq := range seqs {
+			for v := range seq {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Concat2 returns a sequence containing all entries of seqs, one after another.
+func Concat2[K, V any](seqs ...iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, seq := range seqs {
+			for k, v := range seq {
+				if !yield(k, v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// FlatMap maps each element to a sequence by convert, and returns a sequence containing all elements of them.
+func FlatMap[T any, R any](seq iter.Seq[T], convert func(v T) iter.Seq[R]) iter.Seq[R] {
+	return func(yield func(R) bool) {
+		for v := range seq {
+			for r := range convert(v) {
+				if !yield(r) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Zip returns a sequence of pairs of elements at the same position in a and b.
+// The sequence stops when either a or b is exhausted.
+func Zip[A, B any](a iter.Seq[A], b iter.Seq[B]) iter.Seq2[A, B] {
+	return func(yield func(A, B) bool) {
+		nextB, stop := iter.Pull(b)
+		defer stop()
+		for va := range a {
+			vb, ok := nextB()
+			if !ok || !yield(va, vb) {
+				return
+			}
+		}
+	}
+}
+
+// ZipLongest returns a sequence of pairs of elements at the same position in a and b.
+// The sequence stops when both a and b are exhausted, the missing elements of the shorter one are empty optionals.
+func ZipLongest[A, B any](a iter.Seq[A], b iter.Seq[B]) iter.Seq2[optional.Optional[A], optional.Optional[B]] {
+	return func(yield func(optional.Optional[A], optional.Optional[B]) bool) {
+		nextA, stopA := iter.Pull(a)
+		defer stopA()
+		nextB, stopB := iter.Pull(b)
+		defer stopB()
+		for {
+			va, okA := nextA()
+			vb, okB := nextB()
+			if !okA && !okB {
+				return
+			}
+			if !yield(optional.Of(va, okA), optional.Of(vb, okB)) {
+				return
+			}
+		}
+	}
+}
+
+// Unzip splits a Seq2 into a sequence of keys and a sequence of values.
+// Each of the returned sequences iterates seq independently.
+func Unzip[K, V any](seq iter.Seq2[K, V]) (iter.Seq[K], iter.Seq[V]) {
+	keys := func(yield func(K) bool) {
+		for k := range seq {
+			if !yield(k) {
+				break
+			}
+		}
+	}
+	values := func(yield func(V) bool) {
+		for _, v := range seq {
+			if !yield(v) {
+				break
+			}
+		}
+	}
+	return keys, values
+}
+
+// Interleave returns a sequence takes one element from each of seqs in turn. Exhausted sequences are skipped,
+// and the sequence stops when all seqs are exhausted.
+func Interleave[T any](seqs ...iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		nexts := make([]func() (T, bool), 0, len(seqs))
+		for _, seq := range seqs {
+			next, stop := iter.Pull(seq)
+			defer stop()
+			nexts = append(nexts, next)
+		}
+		for len(nexts) > 0 {
+			remained := nexts[:0]
+			for _, next := range nexts {
+				v, ok := next()
+				if !ok {
+					continue
+				}
+				if !yield(v) {
+					return
+				}
+				remained = append(remained, next)
+			}
+			nexts = remained
+		}
+	}
+}
+
+// Reduce combines elements from left to right by func combine, returns empty optional if seq is empty.
+func Reduce[T any](seq iter.Seq[T], combine func(acc T, v T) T) optional.Optional[T] {
+	var acc T
+	present := false
+	for v := range seq {
+		if present {
+			acc = combine(acc, v)
+		} else {
+			acc, present = v, true
+		}
+	}
+	return optional.Of(acc, present)
+}
+
+// Reduce2 combines entries from left to right by func combine, returns empty optional if seq is empty.
+func Reduce2[K, V any](seq iter.Seq2[K, V], combine func(k1 K, v1 V, k2 K, v2 V) (K, V)) optional.Optional[pair.Pair[K, V]] {
+	return Reduce(MapToPairSeq(seq), func(a, b pair.Pair[K, V]) pair.Pair[K, V] {
+		return pair.Of(combine(a.Key(), a.Value(), b.Key(), b.Value()))
+	})
+}
+
+// Fold accumulates elements from left to right by func accumulate, starting with initial value.
+func Fold[T any, R any](seq iter.Seq[T], initial R, accumulate func(acc R, v T) R) R {
+	acc := initial
+	for v := range seq {
+		acc = accumulate(acc, v)
+	}
+	return acc
+}
+
+// Fold2 accumulates entries from left to right by func accumulate, starting with initial value.
+func Fold2[K, V any, R any](seq iter.Seq2[K, V], initial R, accumulate func(acc R, k K, v V) R) R {
+	acc := initial
+	for k, v := range seq {
+		acc = accumulate(acc, k, v)
+	}
+	return acc
+}
+
+// Count returns the number of elements.
+func Count[T any](seq iter.Seq[T]) int {
+	n := 0
+	for range seq {
+		n++
+	}
+	return n
+}
+
+// Count2 returns the number of entries.
+func Count2[K, V any](seq iter.Seq2[K, V]) int {
+	n := 0
+	for range seq {
+		n++
+	}
+	return n
+}
+
+// Sum returns the sum of elements, or zero if seq is empty.
+func Sum[T ints.Types | floats.Types](seq iter.Seq[T]) T {
+	var sum T
+	for v := range seq {
+		sum += v
+	}
+	return sum
+}
+
+// Min returns the least element, returns empty optional if seq is empty.
+// For floating-point elements, Min propagates NaNs like the builtin min.
+func Min[T cmp.Ordered](seq iter.Seq[T]) optional.Optional[T] {
+	return Reduce(seq, func(a, b T) T { return min(a, b) })
+}
+
+// Max returns the greatest element, returns empty optional if seq is empty.
+// For floating-point elements, Max propagates NaNs like the builtin max.
+func Max[T cmp.Ordered](seq iter.Seq[T]) optional.Optional[T] {
+	return Reduce(seq, func(a, b T) T { return max(a, b) })
+}
+
+// MinBy returns the least element by compare func, returns empty optional if seq is empty.
+// If there are multiple least elements, the first one is returned.
+func MinBy[T any](seq iter.Seq[T], compare func(a, b T) int) optional.Optional[T] {
+	return Reduce(seq, func(a, b T) T {
+		if compare(b, a) < 0 {
+			return b
+		}
+		return a
+	})
+}
+
+// MaxBy returns the greatest element by compare func, returns empty optional if seq is empty.
+// If there are multiple greatest elements, the first one is returned.
+func MaxBy[T any](seq iter.Seq[T], compare func(a, b T) int) optional.Optional[T] {
+	return Reduce(seq, func(a, b T) T {
+		if compare(b, a) > 0 {
+			return b
+		}
+		return a
+	})
+}
+
+// MinBy2 returns the least entry by compare func, returns empty optional if seq is empty.
+// If there are multiple least entries, the first one is returned.
+func MinBy2[K, V any](seq iter.Seq2[K, V], compare func(k1 K, v1 V, k2 K, v2 V) int) optional.Optional[pair.Pair[K, V]] {
+	return MinBy(MapToPairSeq(seq), func(a, b pair.Pair[K, V]) int {
+		return compare(a.Key(), a.Value(), b.Key(), b.Value())
+	})
+}
+
+// MaxBy2 returns the greatest entry by compare func, returns empty optional if seq is empty.
+// If there are multiple greatest entries, the first one is returned.
+func MaxBy2[K, V any](seq iter.Seq2[K, V], compare func(k1 K, v1 V, k2 K, v2 V) int) optional.Optional[pair.Pair[K, V]] {
+	return MaxBy(MapToPairSeq(seq), func(a, b pair.Pair[K, V]) int {
+		return compare(a.Key(), a.Value(), b.Key(), b.Value())
+	})
+}
+
+// First returns the first element, returns empty optional if seq is empty.
+func First[T any](seq iter.Seq[T]) optional.Optional[T] {
+	for v := range seq {
+		return optional.OfValue(v)
+	}
+	return optional.Empty[T]()
+}
+
+// First2 returns the first entry, returns empty optional if seq is empty.
+func First2[K, V any](seq iter.Seq2[K, V]) optional.Optional[pair.Pair[K, V]] {
+	for k, v := range seq {
+		return optional.OfValue(pair.Of(k, v))
+	}
+	return optional.Empty[pair.Pair[K, V]]()
+}
+
+// Last returns the last element, returns empty optional if seq is empty.
+func Last[T any](seq iter.Seq[T]) optional.Optional[T] {
+	return Reduce(seq, func(_, v T) T { return v })
+}
+
+// Last2 returns the last entry, returns empty optional if seq is empty.
+func Last2[K, V any](seq iter.Seq2[K, V]) optional.Optional[pair.Pair[K, V]] {
+	return Last(MapToPairSeq(seq))
+}
+
+// Find returns the first element accepted by predicate, returns empty optional if not found.
+func Find[T any](seq iter.Seq[T], predicate func(v T) bool) optional.Optional[T] {
+	return First(Filter(seq, predicate))
+}
+
+// Find2 returns the first entry accepted by predicate, returns empty optional if not found.
+func Find2[K, V any](seq iter.Seq2[K, V], predicate func(k K, v V) bool) optional.Optional[pair.Pair[K, V]] {
+	return First2(Filter2(seq, predicate))
+}
+
+// AnyMatch returns true if any element is accepted by predicate. It returns false if seq is empty.
+func AnyMatch[T any](seq iter.Seq[T], predicate func(v T) bool) bool {
+	for v := range seq {
+		if predicate(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// AnyMatch2 returns true if any entry is accepted by predicate. It returns false if seq is empty.
+func AnyMatch2[K, V any](seq iter.Seq2[K, V], predicate func(k K, v V) bool) bool {
+	for k, v := range seq {
+		if predicate(k, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllMatch returns true if all elements are accepted by predicate. It returns true if seq is empty.
+func AllMatch[T any](seq iter.Seq[T], predicate func(v T) bool) bool {
+	return !AnyMatch(seq, func(v T) bool { return !predicate(v) })
+}
+
+// AllMatch2 returns true if all entries are accepted by predicate. It returns true if seq is empty.
+func AllMatch2[K, V any](seq iter.Seq2[K, V], predicate func(k K, v V) bool) bool {
+	return !AnyMatch2(seq, func(k K, v V) bool { return !predicate(k, v) })
+}
+
+// NoneMatch returns true if no element is accepted by predicate. It returns true if seq is empty.
+func NoneMatch[T any](seq iter.Seq[T], predicate func(v T) bool) bool {
+	return !AnyMatch(seq, predicate)
+}
+
+// NoneMatch2 returns true if no entry is accepted by predicate. It returns true if seq is empty.
+func NoneMatch2[K, V any](seq iter.Seq2[K, V], predicate func(k K, v V) bool) bool {
+	return !AnyMatch2(seq, predicate)
+}
+
+// Join concatenates the strings, with sep placed between them.
+func Join(seq iter.Seq[string], sep string) string {
+	var sb strings.Builder
+	first := true
+	for s := range seq {
+		if !first {
+			sb.WriteString(sep)
+		}
+		sb.WriteString(s)
+		first = false
+	}
+	return sb.String()
+}
@@ -0,0 +1,191 @@
+package iters
+
+import (
+	"cmp"
+	"iter"
+	"maps"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/hsiafan/go-utils/collection/pair"
+	"github.com/hsiafan/go-utils/lang/optional"
+	"github.com/stretchr/testify/assert"
+)
+
+// tracked returns a sequence of values, which sets done to true when the iteration finishes, either exhausted or stopped.
+func tracked[T any](done *bool, values ...T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		defer func() { *done = true }()
+		for _, v := range values {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+func TestConcat(t *testing.T) {
+	seq := Concat(slices.Values([]int{1, 2}), slices.Values([]int{}), slices.Values([]int{3}))
+	assert.Equal(t, []int{1, 2, 3}, slices.Collect(seq))
+	assert.Equal(t, []int{1, 2}, slices.Collect(Take(seq, 2)))
+	assert.Empty(t, slices.Collect(Concat[int]()))
+
+	seq2 := Concat2(maps.All(map[string]int{"a": 1}), maps.All(map[string]int{"b": 2}))
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, maps.Collect(seq2))
+}
+
+func TestFlatMap(t *testing.T) {
+	seq := FlatMap(slices.Values([]int{1, 2, 3}), func(v int) iter.Seq[int] {
+		return slices.Values(slices.Repeat([]int{v}, v))
+	})
+	assert.Equal(t, []int{1, 2, 2, 3, 3, 3}, slices.Collect(seq))
+	assert.Equal(t, []int{1, 2, 2}, slices.Collect(Take(seq, 3)))
+}
+
+func TestZip(t *testing.T) {
+	var doneA, doneB bool
+	seq := Zip(tracked(&doneA, 1, 2, 3), tracked(&doneB, "a", "b"))
+	var as []int
+	var bs []string
+	for a, b := range seq {
+		as = append(as, a)
+		bs = append(bs, b)
+	}
+	assert.Equal(t, []int{1, 2}, as)
+	assert.Equal(t, []string{"a", "b"}, bs)
+	assert.True(t, doneA)
+	assert.True(t, doneB)
+
+	doneA, doneB = false, false
+	for range seq {
+		break
+	}
+	assert.True(t, doneA)
+	assert.True(t, doneB)
+}
+
+func TestZipLongest(t *testing.T) {
+	var as []optional.Optional[int]
+	var bs []optional.Optional[string]
+	for a, b := range ZipLongest(slices.Values([]int{1, 2, 3}), slices.Values([]string{"a"})) {
+		as = append(as, a)
+		bs = append(bs, b)
+	}
+	assert.Equal(t, []optional.Optional[int]{optional.OfValue(1), optional.OfValue(2), optional.OfValue(3)}, as)
+	assert.Equal(t, []optional.Optional[string]{optional.OfValue("a"), optional.Empty[string](), optional.Empty[string]()}, bs)
+
+	var doneA, doneB bool
+	for range ZipLongest(tracked(&doneA, 1, 2), tracked(&doneB, 1, 2)) {
+		break
+	}
+	assert.True(t, doneA)
+	assert.True(t, doneB)
+}
+
+func TestUnzip(t *testing.T) {
+	keys, values := Unzip(Indexed(slices.Values([]string{"a", "b", "c"})))
+	assert.Equal(t, []int{0, 1, 2}, slices.Collect(keys))
+	assert.Equal(t, []string{"a", "b", "c"}, slices.Collect(values))
+	assert.Equal(t, []string{"a"}, slices.Collect(Take(values, 1)))
+}
+
+func TestInterleave(t *testing.T) {
+	seq := Interleave(slices.Values([]int{1, 4}), slices.Values([]int{2}), slices.Values([]int{3, 5, 6}))
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, slices.Collect(seq))
+	assert.Equal(t, []int{1, 2, 3, 4}, slices.Collect(Take(seq, 4)))
+	assert.Empty(t, slices.Collect(Interleave[int]()))
+
+	var doneA, doneB bool
+	for v := range Interleave(tracked(&doneA, 1, 3), tracked(&doneB, 2, 4)) {
+		if v == 2 {
+			break
+		}
+	}
+	assert.True(t, doneA)
+	assert.True(t, doneB)
+}
+
+func TestReduceAndFold(t *testing.T) {
+	seq := slices.Values([]int{1, 2, 3, 4})
+	assert.Equal(t, 24, Reduce(seq, func(a, b int) int { return a * b }).Get())
+	assert.True(t, Reduce(slices.Values([]int{}), func(a, b int) int { return a + b }).IsEmpty())
+	assert.Equal(t, "1234", Fold(seq, "", func(acc string, v int) string { return acc + strconv.Itoa(v) }))
+	assert.Equal(t, 3, Fold2(Indexed(seq), 0, func(acc int, i int, v int) int { return max(acc, i) }))
+	// the greatest index, with the product of values
+	reduced := Reduce2(Indexed(seq), func(i1 int, v1 int, i2 int, v2 int) (int, int) { return max(i1, i2), v1 * v2 })
+	assert.Equal(t, pair.Of(3, 24), reduced.Get())
+	assert.True(t, Reduce2(Indexed(slices.Values([]int{})), func(i1 int, v1 int, i2 int, v2 int) (int, int) {
+		return i2, v2
+	}).IsEmpty())
+
+	assert.Equal(t, 4, Count(seq))
+	assert.Equal(t, 2, Count2(maps.All(map[string]int{"a": 1, "b": 2})))
+	assert.Equal(t, 10, Sum(seq))
+	assert.Equal(t, 3.5, Sum(slices.Values([]float64{1.5, 2})))
+	assert.Equal(t, uint8(0), Sum(slices.Values([]uint8{})))
+}
+
+func TestMinMax(t *testing.T) {
+	seq := slices.Values([]string{"bb", "a", "ccc", "dd"})
+	assert.Equal(t, "a", Min(seq).Get())
+	assert.Equal(t, "dd", Max(seq).Get())
+	assert.True(t, Min(slices.Values([]int{})).IsEmpty())
+
+	byLen := func(a, b string) int { return cmp.Compare(len(a), len(b)) }
+	assert.Equal(t, "a", MinBy(seq, byLen).Get())
+	assert.Equal(t, "ccc", MaxBy(seq, byLen).Get())
+	assert.Equal(t, "bb", MinBy(slices.Values([]string{"bb", "dd"}), byLen).Get())
+	assert.Equal(t, "bb", MaxBy(slices.Values([]string{"bb", "dd"}), byLen).Get())
+	assert.True(t, MaxBy(slices.Values([]string{}), byLen).IsEmpty())
+
+	byValue := func(_ int, a string, _ int, b string) int { return byLen(a, b) }
+	assert.Equal(t, pair.Of(1, "a"), MinBy2(Indexed(seq), byValue).Get())
+	assert.Equal(t, pair.Of(2, "ccc"), MaxBy2(Indexed(seq), byValue).Get())
+}
+
+func TestFirstLastFind(t *testing.T) {
+	var done bool
+	assert.Equal(t, 1, First(tracked(&done, 1, 2, 3)).Get())
+	assert.True(t, done)
+	assert.True(t, First(slices.Values([]int{})).IsEmpty())
+	assert.Equal(t, 3, Last(slices.Values([]int{1, 2, 3})).Get())
+	assert.True(t, Last(slices.Values([]int{})).IsEmpty())
+
+	seq2 := Indexed(slices.Values([]string{"a", "b", "c"}))
+	assert.Equal(t, pair.Of(0, "a"), First2(seq2).Get())
+	assert.Equal(t, pair.Of(2, "c"), Last2(seq2).Get())
+
+	assert.Equal(t, 2, Find(slices.Values([]int{1, 2, 3, 4}), func(v int) bool { return v%2 == 0 }).Get())
+	assert.True(t, Find(slices.Values([]int{1, 3}), func(v int) bool { return v%2 == 0 }).IsEmpty())
+	assert.Equal(t, pair.Of(1, "b"), Find2(seq2, func(_ int, v string) bool { return v > "a" }).Get())
+	assert.True(t, Find2(seq2, func(i int, _ string) bool { return i > 5 }).IsEmpty())
+}
+
+func TestMatch(t *testing.T) {
+	even := func(v int) bool { return v%2 == 0 }
+	assert.True(t, AnyMatch(slices.Values([]int{1, 2}), even))
+	assert.False(t, AnyMatch(slices.Values([]int{}), even))
+	assert.True(t, AllMatch(slices.Values([]int{2, 4}), even))
+	assert.False(t, AllMatch(slices.Values([]int{2, 3}), even))
+	assert.True(t, AllMatch(slices.Values([]int{}), even))
+	assert.True(t, NoneMatch(slices.Values([]int{1, 3}), even))
+	assert.False(t, NoneMatch(slices.Values([]int{1, 2}), even))
+
+	var done bool
+	assert.True(t, AnyMatch(tracked(&done, 1, 2, 3), even))
+	assert.True(t, done)
+
+	seq2 := Indexed(slices.Values([]int{0, 1, 2}))
+	same := func(i int, v int) bool { return i == v }
+	assert.True(t, AllMatch2(seq2, same))
+	assert.True(t, AnyMatch2(seq2, func(i int, _ int) bool { return i == 2 }))
+	assert.False(t, NoneMatch2(seq2, same))
+}
+
+func TestJoin(t *testing.T) {
+	assert.Equal(t, "a, b, c", Join(slices.Values([]string{"a", "b", "c"}), ", "))
+	assert.Equal(t, "a", Join(slices.Values([]string{"a"}), ", "))
+	assert.Equal(t, "", Join(slices.Values([]string{}), ", "))
+	assert.Equal(t, "1-2", Join(Map(slices.Values([]int{1, 2}), strconv.Itoa), "-"))
+}
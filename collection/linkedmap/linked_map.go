@@ -1,6 +1,7 @@
 package linkedmap
 
 import (
+	"errors"
 	"iter"
 
 	"github.com/hsiafan/go-utils/lang/optional"
@@ -14,11 +15,23 @@ type node[K comparable, V any] struct {
 	next *node[K, V]
 }
 
+// ErrorConcurrentModification is the panic value when the map is structurally modified during iteration,
+// other than removing the entry currently being visited.
+var ErrorConcurrentModification = errors.New("linkedmap: concurrent modification during iteration")
+
 // Map is a map with nodes maintained by a linked list, it can keep the order of keys.
+//
+// Iteration over the sequences returned by [Map.All], [Map.Keys] and [Map.Values] tolerates removing
+// the entry currently being visited, and setting value of existing keys. Any other structural modification
+// (adding new keys, removing other keys, clearing the map) during iteration will cause the iteration panics
+// with [ErrorConcurrentModification]. To remove multi entries, use [Map.DeleteFunc].
+//
+// A Map is not safe for concurrent use. syncmap.Map is concurrent-safe, but does not keep the order of keys.
 type Map[K comparable, V any] struct {
-	m    map[K]*node[K, V]
-	head *node[K, V]
-	tail *node[K, V]
+	m        map[K]*node[K, V]
+	head     *node[K, V]
+	tail     *node[K, V]
+	modCount int
 }
 
 // New creates a new LinkedMap.
@@ -35,7 +48,10 @@ func (m *Map[K, V]) Contains(k K) bool {
 // Get returns value for key.
 func (m *Map[K, V]) Get(k K) optional.Optional[V] {
 	n, ok := m.m[k]
-	return optional.Of(n.v, ok)
+	if !ok {
+		return optional.Empty[V]()
+	}
+	return optional.OfValue(n.v)
 }
 
 // Put adds or sets value for key.
@@ -47,6 +63,7 @@ func (m *Map[K, V]) Put(k K, v V) {
 	n := &node[K, V]{k: k, v: v}
 	m.m[k] = n
 	m.insertNode(n)
+	m.modCount++
 }
 
 // PutMap adds/sets all key-values in another map.
@@ -72,6 +89,7 @@ func (m *Map[K, V]) Remove(k K) {
 	if n, ok := m.m[k]; ok {
 		m.removeNode(n)
 		delete(m.m, k)
+		m.modCount++
 	}
 }
 
@@ -93,6 +111,43 @@ func (m *Map[K, V]) removeNode(n *node[K, V]) {
 	} else {
 		n.next.prev = n.prev
 	}
+	n.prev = nil
+	n.next = nil
+}
+
+// DeleteFunc removes all key-values for which del returns true.
+func (m *Map[K, V]) DeleteFunc(del func(K, V) bool) {
+	for n := m.head; n != nil; {
+		next := n.next
+		if del(n.k, n.v) {
+			m.removeNode(n)
+			delete(m.m, n.k)
+			m.modCount++
+		}
+		n = next
+	}
+}
+
+// nodes returns a sequence of all nodes, in the order they were added to map.
+// Removing the node currently yielded is allowed, other structural modifications cause a panic.
+func (m *Map[K, V]) nodes() iter.Seq[*node[K, V]] {
+	return func(yield func(*node[K, V]) bool) {
+		expected := m.modCount
+		for n := m.head; n != nil; {
+			next := n.next
+			if !yield(n) {
+				break
+			}
+			if m.modCount != expected {
+				if m.modCount != expected+1 || m.m[n.k] == n {
+					panic(ErrorConcurrentModification)
+				}
+				// only the current node has been removed
+				expected = m.modCount
+			}
+			n = next
+		}
+	}
 }
 
 // Copy returns a new LinkedMap with same key-values.
@@ -108,7 +163,7 @@ func (m *Map[K, V]) Copy() *Map[K, V] {
 // The order of key-value pairs is the same as they were added to map.
 func (m *Map[K, V]) All() iter.Seq2[K, V] {
 	return func(yield func(K, V) bool) {
-		for n := m.head; n != nil; n = n.next {
+		for n := range m.nodes() {
 			if !yield(n.k, n.v) {
 				break
 			}
@@ -120,7 +175,7 @@ func (m *Map[K, V]) All() iter.Seq2[K, V] {
 // The order of keys is the same as they were added to map.
 func (m *Map[K, V]) Keys() iter.Seq[K] {
 	return func(yield func(K) bool) {
-		for n := m.head; n != nil; n = n.next {
+		for n := range m.nodes() {
 			if !yield(n.k) {
 				break
 			}
@@ -132,7 +187,7 @@ func (m *Map[K, V]) Keys() iter.Seq[K] {
 // The order of values is the same as key-values pairs were added to map.
 func (m *Map[K, V]) Values() iter.Seq[V] {
 	return func(yield func(V) bool) {
-		for n := m.head; n != nil; n = n.next {
+		for n := range m.nodes() {
 			if !yield(n.v) {
 				break
 			}
@@ -147,7 +202,15 @@ func (m *Map[K, V]) Size() int {
 
 // Clear clears the map.
 func (m *Map[K, V]) Clear() {
+	for n := m.head; n != nil; {
+		next := n.next
+		n.prev = nil
+		n.next = nil
+		n = next
+	}
 	clear(m.m)
 	m.head = nil
 	m.tail = nil
+	// increase by 2, so running iterations can not take it as removing the current node
+	m.modCount += 2
 }
@@ -25,6 +25,14 @@ func TestLinkedMap_PutAndRemove(t *testing.T) {
 	assert.Equal(t, []int{1, 3, 4}, slices.Collect(m.Values()))
 }
 
+func TestLinkedMap_GetMissing(t *testing.T) {
+	m := New[string, int]()
+	assert.True(t, m.Get("1").IsEmpty())
+	m.Put("1", 1)
+	m.Remove("1")
+	assert.True(t, m.Get("1").IsEmpty())
+}
+
 func TestLinkedMap_Copy(t *testing.T) {
 	m := New[string, int]()
 	m.Put("1", 1)
@@ -59,3 +67,82 @@ func TestLinkedMap_Clear(t *testing.T) {
 	m.Put("1", 1)
 	assert.Equal(t, 1, m.Size())
 }
+
+func TestLinkedMap_RemoveWhileIterating(t *testing.T) {
+	m := New[string, int]()
+	m.Put("1", 1)
+	m.Put("2", 2)
+	m.Put("3", 3)
+	m.Put("4", 4)
+
+	var visited []string
+	for k, v := range m.All() {
+		visited = append(visited, k)
+		if v%2 == 0 {
+			m.Remove(k)
+		}
+	}
+	assert.Equal(t, []string{"1", "2", "3", "4"}, visited)
+	assert.Equal(t, []string{"1", "3"}, slices.Collect(m.Keys()))
+
+	for k := range m.Keys() {
+		m.Remove(k)
+	}
+	assert.Equal(t, 0, m.Size())
+}
+
+func TestLinkedMap_ModifyWhileIterating(t *testing.T) {
+	m := New[string, int]()
+	m.Put("1", 1)
+	m.Put("2", 2)
+	m.Put("3", 3)
+
+	assert.PanicsWithValue(t, ErrorConcurrentModification, func() {
+		for k := range m.Keys() {
+			if k == "1" {
+				m.Remove("2")
+			}
+		}
+	})
+	assert.PanicsWithValue(t, ErrorConcurrentModification, func() {
+		for range m.Values() {
+			m.Put("4", 4)
+		}
+	})
+
+	assert.PanicsWithValue(t, ErrorConcurrentModification, func() {
+		for k := range m.Keys() {
+			if k == "1" {
+				m.Clear()
+			}
+		}
+	})
+	assert.Equal(t, 0, m.Size())
+	m.Put("1", 1)
+	m.Put("3", 3)
+	m.Put("4", 4)
+
+	// setting value of existing key is not a structural modification
+	for k, v := range m.All() {
+		m.Put(k, v*10)
+	}
+	assert.Equal(t, []int{10, 30, 40}, slices.Collect(m.Values()))
+}
+
+func TestLinkedMap_DeleteFunc(t *testing.T) {
+	m := New[string, int]()
+	m.Put("1", 1)
+	m.Put("2", 2)
+	m.Put("3", 3)
+	m.Put("4", 4)
+
+	m.DeleteFunc(func(k string, v int) bool {
+		return v%2 == 1
+	})
+	assert.Equal(t, 2, m.Size())
+	assert.False(t, m.Contains("1"))
+	assert.Equal(t, []string{"2", "4"}, slices.Collect(m.Keys()))
+
+	m.Put("1", 1)
+	assert.Equal(t, []string{"2", "4", "1"}, slices.Collect(m.Keys()))
+}
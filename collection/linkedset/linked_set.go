@@ -3,14 +3,19 @@ package linkedset
 import (
 	"iter"
 
+	"github.com/hsiafan/go-utils/collection"
 	"github.com/hsiafan/go-utils/collection/linkedmap"
 )
 
 type empty struct{}
 
 // Set is a set that keeps the order of elements.
+//
+// A Set is not safe for concurrent use. syncset.Set is concurrent-safe, but does not keep the order of elements.
 type Set[T comparable] linkedmap.Map[T, empty]
 
+var _ collection.Set[int] = (*Set[int])(nil)
+
 // New creates a new LinkedSet.
 func New[T comparable](values ...T) *Set[T] {
 	m := linkedmap.New[T, empty]()
@@ -20,6 +25,15 @@ func New[T comparable](values ...T) *Set[T] {
 	return (*Set[T])(m)
 }
 
+// Collect collects items into a LinkedSet.
+func Collect[T comparable](seq iter.Seq[T]) *Set[T] {
+	s := New[T]()
+	for v := range seq {
+		s.Add(v)
+	}
+	return s
+}
+
 // Contains reports is given value exists in this set
 func (s *Set[T]) Contains(v T) bool {
 	return s.m().Contains(v)
@@ -68,7 +82,7 @@ func (s *Set[T]) All() iter.Seq[T] {
 
 // ToSlice return a slice contains the elements in the set.
 func (s *Set[T]) ToSlice() []T {
-	slice := make([]T, s.Size())
+	slice := make([]T, 0, s.Size())
 	for v := range s.All() {
 		slice = append(slice, v)
 	}
@@ -85,6 +99,48 @@ func (s *Set[T]) Clear() {
 	s.m().Clear()
 }
 
+// Union returns a new set contains elements in one of sets.
+// The elements of s come first, followed by the elements only in s2.
+func (s *Set[T]) Union(s2 collection.SetView[T]) *Set[T] {
+	return Collect(collection.Union(s, s2))
+}
+
+// Intersection returns a new set contains element in both sets, keeping the order of s.
+func (s *Set[T]) Intersection(s2 collection.SetView[T]) *Set[T] {
+	return Collect(collection.Intersection(s, s2))
+}
+
+// Difference returns a set contains elements in s but not in s2, keeping the order of s.
+func (s *Set[T]) Difference(s2 collection.SetView[T]) *Set[T] {
+	return Collect(collection.Difference(s, s2))
+}
+
+// SymmetricDifference returns a set contains elements in only one of sets.
+// The elements only in s come first, followed by the elements only in s2.
+func (s *Set[T]) SymmetricDifference(s2 collection.SetView[T]) *Set[T] {
+	return Collect(collection.SymmetricDifference(s, s2))
+}
+
+// IsSubsetOf reports whether all elements of s are in s2.
+func (s *Set[T]) IsSubsetOf(s2 collection.SetView[T]) bool {
+	return collection.IsSubsetOf(s, s2)
+}
+
+// IsSupersetOf reports whether all elements of s2 are in s.
+func (s *Set[T]) IsSupersetOf(s2 collection.SetView[T]) bool {
+	return collection.IsSupersetOf(s, s2)
+}
+
+// IsDisjoint reports whether s and s2 have no element in common.
+func (s *Set[T]) IsDisjoint(s2 collection.SetView[T]) bool {
+	return collection.IsDisjoint(s, s2)
+}
+
+// Equal reports whether s and s2 contain the same elements, regardless of order.
+func (s *Set[T]) Equal(s2 collection.SetView[T]) bool {
+	return collection.Equal(s, s2)
+}
+
 func (s *Set[T]) m() *linkedmap.Map[T, empty] {
 	return (*linkedmap.Map[T, empty])(s)
 }
@@ -72,3 +72,21 @@ func TestLinkedSet_Copy(t *testing.T) {
 	assert.True(t, copied.Contains(2))
 	assert.True(t, copied.Contains(3))
 }
+
+func TestLinkedSet_ToSlice(t *testing.T) {
+	set := New(3, 1, 2)
+	assert.Equal(t, []int{3, 1, 2}, set.ToSlice())
+}
+
+func TestLinkedSet_Algebra(t *testing.T) {
+	s1 := New(3, 2, 1)
+	s2 := New(4, 3)
+	assert.Equal(t, []int{3, 2, 1, 4}, s1.Union(s2).ToSlice())
+	assert.Equal(t, []int{3}, s1.Intersection(s2).ToSlice())
+	assert.Equal(t, []int{2, 1}, s1.Difference(s2).ToSlice())
+	assert.Equal(t, []int{2, 1, 4}, s1.SymmetricDifference(s2).ToSlice())
+	assert.True(t, New(1, 2).IsSubsetOf(s1))
+	assert.True(t, s1.IsSupersetOf(New(1, 2)))
+	assert.True(t, New(5).IsDisjoint(s1))
+	assert.True(t, s1.Equal(New(1, 2, 3)))
+}
@@ -0,0 +1,148 @@
+package multimap
+
+import (
+	"iter"
+	"slices"
+)
+
+// ListMultimap is a map that associates a key with multi values, the values of a key are kept in insertion order,
+// and may contain duplicates. Values need not be comparable; the operations comparing values,
+// [ContainsEntry], [Remove] and [Inverse], are functions requiring comparable values.
+//
+// The zero ListMultimap is empty and ready for use.
+type ListMultimap[K comparable, V any] struct {
+	m    map[K][]V
+	size int
+}
+
+// NewList creates a new ListMultimap.
+func NewList[K comparable, V any]() *ListMultimap[K, V] {
+	return &ListMultimap[K, V]{m: make(map[K][]V)}
+}
+
+// Put adds a value for key.
+func (m *ListMultimap[K, V]) Put(k K, v V) {
+	m.PutAll(k, v)
+}
+
+// PutAll adds values for key.
+func (m *ListMultimap[K, V]) PutAll(k K, values ...V) {
+	if len(values) == 0 {
+		return
+	}
+	if m.m == nil {
+		m.m = make(map[K][]V)
+	}
+	m.m[k] = append(m.m[k], values...)
+	m.size += len(values)
+}
+
+// Get returns a copy of values for key, in insertion order. It returns nil if key not exists.
+func (m *ListMultimap[K, V]) Get(k K) []V {
+	return slices.Clone(m.m[k])
+}
+
+// ContainsKey reports whether there is any value for key.
+func (m *ListMultimap[K, V]) ContainsKey(k K) bool {
+	_, ok := m.m[k]
+	return ok
+}
+
+// ContainsEntryFunc reports whether there is a value for key satisfies match.
+func (m *ListMultimap[K, V]) ContainsEntryFunc(k K, match func(V) bool) bool {
+	return slices.ContainsFunc(m.m[k], match)
+}
+
+// RemoveFunc removes the first value for key satisfies match, returns true if it existed.
+func (m *ListMultimap[K, V]) RemoveFunc(k K, match func(V) bool) bool {
+	values := m.m[k]
+	i := slices.IndexFunc(values, match)
+	if i < 0 {
+		return false
+	}
+	values = slices.Delete(values, i, i+1)
+	if len(values) == 0 {
+		delete(m.m, k)
+	} else {
+		m.m[k] = values
+	}
+	m.size--
+	return true
+}
+
+// RemoveAll removes all values for key, returns the removed values.
+func (m *ListMultimap[K, V]) RemoveAll(k K) []V {
+	values := m.m[k]
+	delete(m.m, k)
+	m.size -= len(values)
+	return values
+}
+
+// Keys returns the distinct keys as a [iter.Seq], in unspecified order.
+func (m *ListMultimap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range m.m {
+			if !yield(k) {
+				break
+			}
+		}
+	}
+}
+
+// Entries returns all key-values as a [iter.Seq2]. Keys are in unspecified order,
+// the values of a key are in insertion order.
+func (m *ListMultimap[K, V]) Entries() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, values := range m.m {
+			for _, v := range values {
+				if !yield(k, v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// AsMap returns a new builtin map with copies of values of each key.
+func (m *ListMultimap[K, V]) AsMap() map[K][]V {
+	r := make(map[K][]V, len(m.m))
+	for k, values := range m.m {
+		r[k] = slices.Clone(values)
+	}
+	return r
+}
+
+// Size returns the count of key-values.
+func (m *ListMultimap[K, V]) Size() int {
+	return m.size
+}
+
+// KeySize returns the count of distinct keys.
+func (m *ListMultimap[K, V]) KeySize() int {
+	return len(m.m)
+}
+
+// Clear removes all key-values.
+func (m *ListMultimap[K, V]) Clear() {
+	clear(m.m)
+	m.size = 0
+}
+
+// ContainsEntry reports whether the key-value exists in the ListMultimap.
+func ContainsEntry[K, V comparable](m *ListMultimap[K, V], k K, v V) bool {
+	return slices.Contains(m.m[k], v)
+}
+
+// Remove removes the first occurrence of the key-value from the ListMultimap, returns true if it existed.
+func Remove[K, V comparable](m *ListMultimap[K, V], k K, v V) bool {
+	return m.RemoveFunc(k, func(e V) bool { return e == v })
+}
+
+// Inverse returns a new ListMultimap with each key-value of m reversed to value-key.
+func Inverse[K, V comparable](m *ListMultimap[K, V]) *ListMultimap[V, K] {
+	r := NewList[V, K]()
+	for k, v := range m.Entries() {
+		r.Put(v, k)
+	}
+	return r
+}
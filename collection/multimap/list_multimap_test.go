@@ -0,0 +1,87 @@
+package multimap
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListMultimap(t *testing.T) {
+	m := NewList[string, string]()
+	m.Put("Accept", "text/html")
+	m.PutAll("Accept", "application/json", "text/html")
+	m.Put("Host", "example.com")
+	m.PutAll("Empty")
+
+	assert.Equal(t, []string{"text/html", "application/json", "text/html"}, m.Get("Accept"))
+	assert.Nil(t, m.Get("Empty"))
+	assert.Equal(t, 4, m.Size())
+	assert.Equal(t, 2, m.KeySize())
+	assert.True(t, m.ContainsKey("Host"))
+	assert.True(t, ContainsEntry(m, "Accept", "application/json"))
+	assert.False(t, ContainsEntry(m, "Host", "application/json"))
+
+	values := m.Get("Accept")
+	values[0] = "changed"
+	assert.Equal(t, "text/html", m.Get("Accept")[0])
+
+	assert.True(t, Remove(m, "Accept", "text/html"))
+	assert.Equal(t, []string{"application/json", "text/html"}, m.Get("Accept"))
+	assert.False(t, Remove(m, "Accept", "text/plain"))
+	assert.True(t, Remove(m, "Host", "example.com"))
+	assert.False(t, m.ContainsKey("Host"))
+	assert.Equal(t, 2, m.Size())
+
+	assert.Equal(t, map[string][]string{"Accept": {"application/json", "text/html"}}, m.AsMap())
+	assert.Equal(t, []string{"Accept"}, slices.Collect(m.Keys()))
+
+	assert.Equal(t, []string{"application/json", "text/html"}, m.RemoveAll("Accept"))
+	assert.Equal(t, 0, m.Size())
+	assert.Equal(t, 0, m.KeySize())
+}
+
+func TestListMultimap_Inverse(t *testing.T) {
+	m := NewList[string, int]()
+	m.PutAll("a", 1, 2)
+	m.PutAll("b", 2)
+
+	var entries int
+	for range m.Entries() {
+		entries++
+	}
+	assert.Equal(t, 3, entries)
+
+	inv := Inverse(m)
+	assert.Equal(t, []string{"a"}, inv.Get(1))
+	assert.ElementsMatch(t, []string{"a", "b"}, inv.Get(2))
+	assert.Equal(t, 3, inv.Size())
+
+	m.Clear()
+	assert.Equal(t, 0, m.Size())
+	assert.False(t, m.ContainsKey("a"))
+}
+
+func TestListMultimap_NonComparable(t *testing.T) {
+	m := NewList[string, []int]()
+	m.Put("a", []int{1})
+	m.PutAll("a", []int{2, 3}, []int{4})
+	assert.Equal(t, [][]int{{1}, {2, 3}, {4}}, m.Get("a"))
+	assert.True(t, m.ContainsEntryFunc("a", func(v []int) bool { return len(v) == 2 }))
+	assert.False(t, m.ContainsEntryFunc("b", func(v []int) bool { return true }))
+	assert.True(t, m.RemoveFunc("a", func(v []int) bool { return v[0] > 1 }))
+	assert.Equal(t, [][]int{{1}, {4}}, m.Get("a"))
+	assert.False(t, m.RemoveFunc("a", func(v []int) bool { return v[0] > 4 }))
+	assert.Equal(t, 2, m.Size())
+}
+
+func TestListMultimap_ZeroValue(t *testing.T) {
+	var m ListMultimap[string, int]
+	assert.Nil(t, m.Get("a"))
+	assert.False(t, Remove(&m, "a", 1))
+	assert.Nil(t, m.RemoveAll("a"))
+	m.Put("a", 1)
+	m.PutAll("b", 2, 3)
+	assert.Equal(t, 3, m.Size())
+	assert.Equal(t, map[string][]int{"a": {1}, "b": {2, 3}}, m.AsMap())
+}
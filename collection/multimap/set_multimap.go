@@ -0,0 +1,156 @@
+package multimap
+
+import (
+	"iter"
+
+	"github.com/hsiafan/go-utils/collection/set"
+)
+
+// SetMultimap is a map that associates a key with multi values, the values of a key are kept in a [set.Set],
+// so there are no duplicated key-values.
+//
+// The zero SetMultimap is empty and ready for use.
+type SetMultimap[K, V comparable] struct {
+	m    map[K]set.Set[V]
+	size int
+}
+
+// NewSet creates a new SetMultimap.
+func NewSet[K, V comparable]() *SetMultimap[K, V] {
+	return &SetMultimap[K, V]{m: make(map[K]set.Set[V])}
+}
+
+// Put adds a value for key. It does nothing if the key-value already exists.
+func (m *SetMultimap[K, V]) Put(k K, v V) {
+	values, ok := m.m[k]
+	if !ok {
+		if m.m == nil {
+			m.m = make(map[K]set.Set[V])
+		}
+		values = set.New[V]()
+		m.m[k] = values
+	} else if values.Contains(v) {
+		return
+	}
+	values.Add(v)
+	m.size++
+}
+
+// PutAll adds values for key.
+func (m *SetMultimap[K, V]) PutAll(k K, values ...V) {
+	for _, v := range values {
+		m.Put(k, v)
+	}
+}
+
+// Get returns values for key as a slice, in unspecified order. It returns nil if key not exists.
+func (m *SetMultimap[K, V]) Get(k K) []V {
+	values, ok := m.m[k]
+	if !ok {
+		return nil
+	}
+	return values.ToSlice()
+}
+
+// GetSet returns a copy of values for key as a set. It returns an empty set if key not exists.
+func (m *SetMultimap[K, V]) GetSet(k K) set.Set[V] {
+	values, ok := m.m[k]
+	if !ok {
+		return set.New[V]()
+	}
+	return values.Copy()
+}
+
+// ContainsKey reports whether there is any value for key.
+func (m *SetMultimap[K, V]) ContainsKey(k K) bool {
+	_, ok := m.m[k]
+	return ok
+}
+
+// ContainsEntry reports whether the key-value exists.
+func (m *SetMultimap[K, V]) ContainsEntry(k K, v V) bool {
+	return m.m[k].Contains(v)
+}
+
+// Remove removes the key-value, returns true if it existed.
+func (m *SetMultimap[K, V]) Remove(k K, v V) bool {
+	values := m.m[k]
+	if !values.Contains(v) {
+		return false
+	}
+	values.Remove(v)
+	if values.Size() == 0 {
+		delete(m.m, k)
+	}
+	m.size--
+	return true
+}
+
+// RemoveAll removes all values for key, returns the removed values in unspecified order.
+func (m *SetMultimap[K, V]) RemoveAll(k K) []V {
+	values, ok := m.m[k]
+	if !ok {
+		return nil
+	}
+	delete(m.m, k)
+	m.size -= values.Size()
+	return values.ToSlice()
+}
+
+// Keys returns the distinct keys as a [iter.Seq], in unspecified order.
+func (m *SetMultimap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range m.m {
+			if !yield(k) {
+				break
+			}
+		}
+	}
+}
+
+// Entries returns all key-values as a [iter.Seq2], in unspecified order.
+func (m *SetMultimap[K, V]) Entries() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, values := range m.m {
+			for v := range values {
+				if !yield(k, v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// AsMap returns a new builtin map with copies of values of each key.
+func (m *SetMultimap[K, V]) AsMap() map[K]set.Set[V] {
+	r := make(map[K]set.Set[V], len(m.m))
+	for k, values := range m.m {
+		r[k] = values.Copy()
+	}
+	return r
+}
+
+// Inverse returns a new SetMultimap with each key-value reversed to value-key.
+func (m *SetMultimap[K, V]) Inverse() *SetMultimap[V, K] {
+	r := NewSet[V, K]()
+	for k, v := range m.Entries() {
+		r.Put(v, k)
+	}
+	return r
+}
+
+// Size returns the count of key-values.
+func (m *SetMultimap[K, V]) Size() int {
+	return m.size
+}
+
+// KeySize returns the count of distinct keys.
+func (m *SetMultimap[K, V]) KeySize() int {
+	return len(m.m)
+}
+
+// Clear removes all key-values.
+func (m *SetMultimap[K, V]) Clear() {
+	clear(m.m)
+	m.size = 0
+}
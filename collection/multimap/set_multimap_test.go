@@ -0,0 +1,49 @@
+package multimap
+
+import (
+	"testing"
+
+	"github.com/hsiafan/go-utils/collection/set"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetMultimap(t *testing.T) {
+	m := NewSet[string, string]()
+	m.Put("go", "lang")
+	m.PutAll("go", "lang", "google")
+	m.Put("rust", "lang")
+
+	assert.ElementsMatch(t, []string{"lang", "google"}, m.Get("go"))
+	assert.Nil(t, m.Get("java"))
+	assert.Equal(t, set.New("lang", "google"), m.GetSet("go"))
+	assert.Equal(t, 0, m.GetSet("java").Size())
+	assert.Equal(t, 3, m.Size())
+	assert.Equal(t, 2, m.KeySize())
+	assert.True(t, m.ContainsEntry("rust", "lang"))
+	assert.False(t, m.ContainsEntry("java", "lang"))
+
+	assert.True(t, m.Remove("go", "google"))
+	assert.False(t, m.Remove("go", "google"))
+	assert.False(t, m.Remove("java", "lang"))
+	assert.Equal(t, map[string]set.Set[string]{"go": set.New("lang"), "rust": set.New("lang")}, m.AsMap())
+
+	inv := m.Inverse()
+	assert.ElementsMatch(t, []string{"go", "rust"}, inv.Get("lang"))
+
+	assert.Equal(t, []string{"lang"}, m.RemoveAll("rust"))
+	assert.Nil(t, m.RemoveAll("rust"))
+	assert.Equal(t, 1, m.Size())
+
+	m.Clear()
+	assert.Equal(t, 0, m.Size())
+}
+
+func TestSetMultimap_ZeroValue(t *testing.T) {
+	var m SetMultimap[string, int]
+	assert.Nil(t, m.Get("a"))
+	assert.False(t, m.ContainsEntry("a", 1))
+	assert.False(t, m.Remove("a", 1))
+	m.PutAll("a", 1, 2, 1)
+	assert.Equal(t, 2, m.Size())
+	assert.ElementsMatch(t, []int{1, 2}, m.Get("a"))
+}
@@ -0,0 +1,252 @@
+package multiset
+
+import (
+	"container/heap"
+	"errors"
+	"iter"
+
+	"github.com/hsiafan/go-utils/collection/pair"
+)
+
+// ErrorNegativeCount is the panic value when a negative count is given.
+var ErrorNegativeCount = errors.New("multiset: negative count")
+
+// Multiset is a set that allows duplicate elements, it keeps the count of each element, likes python's Counter.
+// Elements with zero count are not kept.
+//
+// The zero Multiset is empty and ready for use.
+type Multiset[T comparable] struct {
+	counts map[T]int
+	size   int
+}
+
+// New creates a new Multiset, each of given values is added once.
+func New[T comparable](values ...T) *Multiset[T] {
+	ms := &Multiset[T]{counts: make(map[T]int)}
+	for _, v := range values {
+		ms.Add(v, 1)
+	}
+	return ms
+}
+
+// Collect collects items into a Multiset.
+func Collect[T comparable](seq iter.Seq[T]) *Multiset[T] {
+	ms := New[T]()
+	for v := range seq {
+		ms.Add(v, 1)
+	}
+	return ms
+}
+
+// Add adds n occurrences of element v. It panics if n is negative.
+func (ms *Multiset[T]) Add(v T, n int) {
+	checkCount(n)
+	if n > 0 {
+		if ms.counts == nil {
+			ms.counts = make(map[T]int)
+		}
+		ms.counts[v] += n
+		ms.size += n
+	}
+}
+
+// Remove removes n occurrences of element v. If there are fewer than n occurrences, all are removed.
+// It panics if n is negative.
+func (ms *Multiset[T]) Remove(v T, n int) {
+	checkCount(n)
+	ms.SetCount(v, max(ms.counts[v]-n, 0))
+}
+
+// RemoveAll removes all occurrences of element v.
+func (ms *Multiset[T]) RemoveAll(v T) {
+	ms.SetCount(v, 0)
+}
+
+// SetCount sets the count of element v. It panics if n is negative.
+func (ms *Multiset[T]) SetCount(v T, n int) {
+	checkCount(n)
+	ms.size += n - ms.counts[v]
+	if n == 0 {
+		delete(ms.counts, v)
+	} else {
+		if ms.counts == nil {
+			ms.counts = make(map[T]int)
+		}
+		ms.counts[v] = n
+	}
+}
+
+// Count returns the occurrences of element v, 0 if v not exists.
+func (ms *Multiset[T]) Count(v T) int {
+	return ms.counts[v]
+}
+
+// Contains reports whether v occurs at least once.
+func (ms *Multiset[T]) Contains(v T) bool {
+	_, ok := ms.counts[v]
+	return ok
+}
+
+// Size returns the total count of elements, including duplicates.
+func (ms *Multiset[T]) Size() int {
+	return ms.size
+}
+
+// DistinctSize returns the count of distinct elements.
+func (ms *Multiset[T]) DistinctSize() int {
+	return len(ms.counts)
+}
+
+// Distinct returns the distinct elements as a [iter.Seq], in unspecified order.
+func (ms *Multiset[T]) Distinct() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range ms.counts {
+			if !yield(v) {
+				break
+			}
+		}
+	}
+}
+
+// All returns the distinct elements with their counts as a [iter.Seq2], in unspecified order.
+func (ms *Multiset[T]) All() iter.Seq2[T, int] {
+	return func(yield func(T, int) bool) {
+		for v, n := range ms.counts {
+			if !yield(v, n) {
+				break
+			}
+		}
+	}
+}
+
+// Elements returns all elements as a [iter.Seq], each element is repeated as many times as its count.
+func (ms *Multiset[T]) Elements() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v, n := range ms.counts {
+			for range n {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// MostCommon returns the n most common elements with their counts, from the most common to the least.
+// Elements with equal counts are ordered arbitrarily. If n is negative or larger than the distinct count,
+// all elements are returned.
+func (ms *Multiset[T]) MostCommon(n int) []pair.Pair[T, int] {
+	if n < 0 || n > len(ms.counts) {
+		n = len(ms.counts)
+	}
+	if n == 0 {
+		return []pair.Pair[T, int]{}
+	}
+	// keep the top n in a min-heap, the least common of top n is at the root
+	h := make(countHeap[T], 0, n)
+	for v, c := range ms.counts {
+		if len(h) < n {
+			heap.Push(&h, pair.Of(v, c))
+		} else if c > h[0].Value() {
+			h[0] = pair.Of(v, c)
+			heap.Fix(&h, 0)
+		}
+	}
+	result := make([]pair.Pair[T, int], len(h))
+	for i := len(h) - 1; i >= 0; i-- {
+		result[i] = heap.Pop(&h).(pair.Pair[T, int])
+	}
+	return result
+}
+
+// Copy returns a new Multiset with the same elements and counts.
+func (ms *Multiset[T]) Copy() *Multiset[T] {
+	nms := &Multiset[T]{counts: make(map[T]int, len(ms.counts)), size: ms.size}
+	for v, n := range ms.counts {
+		nms.counts[v] = n
+	}
+	return nms
+}
+
+// Clear removes all elements.
+func (ms *Multiset[T]) Clear() {
+	clear(ms.counts)
+	ms.size = 0
+}
+
+// Equal reports whether the two multisets have the same elements with the same counts.
+func (ms *Multiset[T]) Equal(other *Multiset[T]) bool {
+	if ms.size != other.size || len(ms.counts) != len(other.counts) {
+		return false
+	}
+	for v, n := range ms.counts {
+		if other.counts[v] != n {
+			return false
+		}
+	}
+	return true
+}
+
+// Union returns a new multiset, the count of each element is the max of counts in the two multisets.
+func (ms *Multiset[T]) Union(other *Multiset[T]) *Multiset[T] {
+	r := ms.Copy()
+	for v, n := range other.counts {
+		if n > r.counts[v] {
+			r.SetCount(v, n)
+		}
+	}
+	return r
+}
+
+// Intersection returns a new multiset, the count of each element is the min of counts in the two multisets.
+func (ms *Multiset[T]) Intersection(other *Multiset[T]) *Multiset[T] {
+	r := New[T]()
+	for v, n := range ms.counts {
+		if c := min(n, other.counts[v]); c > 0 {
+			r.SetCount(v, c)
+		}
+	}
+	return r
+}
+
+// Sum returns a new multiset, the count of each element is the sum of counts in the two multisets.
+func (ms *Multiset[T]) Sum(other *Multiset[T]) *Multiset[T] {
+	r := ms.Copy()
+	for v, n := range other.counts {
+		r.Add(v, n)
+	}
+	return r
+}
+
+// Difference returns a new multiset, the count of each element is its count in ms minus its count in other.
+// Elements with non-positive results are not kept.
+func (ms *Multiset[T]) Difference(other *Multiset[T]) *Multiset[T] {
+	r := New[T]()
+	for v, n := range ms.counts {
+		if c := n - other.counts[v]; c > 0 {
+			r.SetCount(v, c)
+		}
+	}
+	return r
+}
+
+func checkCount(n int) {
+	if n < 0 {
+		panic(ErrorNegativeCount)
+	}
+}
+
+// countHeap is a min-heap of element-count pairs, ordered by count
+type countHeap[T any] []pair.Pair[T, int]
+
+func (h countHeap[T]) Len() int           { return len(h) }
+func (h countHeap[T]) Less(i, j int) bool { return h[i].Value() < h[j].Value() }
+func (h countHeap[T]) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *countHeap[T]) Push(x any)        { *h = append(*h, x.(pair.Pair[T, int])) }
+
+func (h *countHeap[T]) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
@@ -0,0 +1,94 @@
+package multiset
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hsiafan/go-utils/collection/pair"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiset(t *testing.T) {
+	ms := New("a", "b", "a")
+	assert.Equal(t, 2, ms.Count("a"))
+	assert.Equal(t, 1, ms.Count("b"))
+	assert.Equal(t, 0, ms.Count("c"))
+	assert.Equal(t, 3, ms.Size())
+	assert.Equal(t, 2, ms.DistinctSize())
+
+	ms.Add("c", 3)
+	ms.Add("d", 0)
+	assert.False(t, ms.Contains("d"))
+	assert.Equal(t, 6, ms.Size())
+
+	ms.Remove("c", 2)
+	assert.Equal(t, 1, ms.Count("c"))
+	ms.Remove("c", 5)
+	assert.False(t, ms.Contains("c"))
+	assert.Equal(t, 3, ms.Size())
+
+	ms.SetCount("b", 4)
+	assert.Equal(t, 6, ms.Size())
+	ms.RemoveAll("b")
+	assert.Equal(t, 2, ms.Size())
+
+	assert.ElementsMatch(t, []string{"a"}, slices.Collect(ms.Distinct()))
+	assert.ElementsMatch(t, []string{"a", "a"}, slices.Collect(ms.Elements()))
+	for v, n := range ms.All() {
+		assert.Equal(t, "a", v)
+		assert.Equal(t, 2, n)
+	}
+
+	assert.PanicsWithValue(t, ErrorNegativeCount, func() { ms.Add("a", -1) })
+
+	ms.Clear()
+	assert.Equal(t, 0, ms.Size())
+	assert.Equal(t, 0, ms.DistinctSize())
+}
+
+func TestMultiset_MostCommon(t *testing.T) {
+	ms := Collect(slices.Values(strings.Split("abracadabra", "")))
+	assert.Equal(t, []pair.Pair[string, int]{pair.Of("a", 5)}, ms.MostCommon(1))
+	top := ms.MostCommon(3)
+	assert.Equal(t, pair.Of("a", 5), top[0])
+	assert.Equal(t, 2, top[1].Value())
+	assert.Equal(t, 2, top[2].Value())
+	assert.ElementsMatch(t, []string{"b", "r"}, []string{top[1].Key(), top[2].Key()})
+
+	all := ms.MostCommon(-1)
+	assert.Equal(t, 5, len(all))
+	assert.Equal(t, 1, all[4].Value())
+	assert.Equal(t, 0, len(ms.MostCommon(0)))
+}
+
+func TestMultiset_Algebra(t *testing.T) {
+	m1 := New("a", "a", "a", "b")
+	m2 := New("a", "b", "b", "c")
+
+	assert.True(t, m1.Union(m2).Equal(New("a", "a", "a", "b", "b", "c")))
+	assert.True(t, m1.Intersection(m2).Equal(New("a", "b")))
+	assert.True(t, m1.Sum(m2).Equal(New("a", "a", "a", "a", "b", "b", "b", "c")))
+	assert.True(t, m1.Difference(m2).Equal(New("a", "a")))
+	assert.True(t, m2.Difference(m1).Equal(New("b", "c")))
+	assert.False(t, m1.Equal(m2))
+
+	c := m1.Copy()
+	c.Add("z", 1)
+	assert.False(t, m1.Contains("z"))
+}
+
+func TestMultiset_ZeroValue(t *testing.T) {
+	var ms Multiset[string]
+	assert.Equal(t, 0, ms.Count("a"))
+	assert.Empty(t, ms.MostCommon(1))
+	ms.Remove("a", 1)
+	ms.SetCount("b", 2)
+	ms.Add("a", 1)
+	assert.Equal(t, 3, ms.Size())
+	assert.True(t, ms.Equal(New("a", "b", "b")))
+
+	var other Multiset[string]
+	assert.True(t, other.Union(&ms).Equal(&ms))
+	assert.Equal(t, 0, other.Intersection(&ms).Size())
+}
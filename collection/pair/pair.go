@@ -1,5 +1,7 @@
 package pair
 
+import "cmp"
+
 // Pair is a key-value pair.
 type Pair[K, V any] struct {
 	key   K
@@ -35,3 +37,48 @@ func (p Pair[K, V]) First() K {
 func (p Pair[K, V]) Second() V {
 	return p.value
 }
+
+// Swap returns a new pair with key and value swapped.
+func (p Pair[K, V]) Swap() Pair[V, K] {
+	return Pair[V, K]{p.value, p.key}
+}
+
+// Compare compares two pairs lexicographically, first by key, then by value.
+// It returns a negative number when a < b, a positive number when a > b and zero when a == b.
+func Compare[K, V cmp.Ordered](a, b Pair[K, V]) int {
+	if c := cmp.Compare(a.key, b.key); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.value, b.value)
+}
+
+// CompareFunc returns a func compares two pairs lexicographically, by compareKey then by compareValue.
+func CompareFunc[K, V any](compareKey func(K, K) int, compareValue func(V, V) int) func(a, b Pair[K, V]) int {
+	return func(a, b Pair[K, V]) int {
+		if c := compareKey(a.key, b.key); c != 0 {
+			return c
+		}
+		return compareValue(a.value, b.value)
+	}
+}
+
+// Zip pairs up keys and values at the same index. If the slices have different lengths, the extra elements of the
+// longer one are ignored.
+func Zip[K, V any](keys []K, values []V) []Pair[K, V] {
+	n := min(len(keys), len(values))
+	ps := make([]Pair[K, V], n)
+	for i := 0; i < n; i++ {
+		ps[i] = Pair[K, V]{keys[i], values[i]}
+	}
+	return ps
+}
+
+// Unzip splits pairs into keys and values.
+func Unzip[K, V any](ps []Pair[K, V]) ([]K, []V) {
+	keys := make([]K, len(ps))
+	values := make([]V, len(ps))
+	for i, p := range ps {
+		keys[i], values[i] = p.key, p.value
+	}
+	return keys, values
+}
@@ -0,0 +1,34 @@
+package pair
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// MarshalJSON encodes the pair as a JSON array of two elements, [key, value].
+func (p Pair[K, V]) MarshalJSON() ([]byte, error) {
+	return json.Marshal([2]any{p.key, p.value})
+}
+
+// UnmarshalJSON decodes a JSON array of two elements into the pair. A JSON null is a no-op.
+func (p *Pair[K, V]) UnmarshalJSON(data []byte) error {
+	var values []json.RawMessage
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	if values == nil {
+		return nil
+	}
+	if len(values) != 2 {
+		return fmt.Errorf("pair: expect JSON array of 2 elements, got %d", len(values))
+	}
+	var decoded Pair[K, V]
+	if err := json.Unmarshal(values[0], &decoded.key); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(values[1], &decoded.value); err != nil {
+		return err
+	}
+	*p = decoded
+	return nil
+}
@@ -0,0 +1,58 @@
+package pair
+
+import (
+	"cmp"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPair(t *testing.T) {
+	p := Of("a", 1)
+	k, v := p.Unpack()
+	assert.Equal(t, "a", k)
+	assert.Equal(t, 1, v)
+	assert.Equal(t, Of(1, "a"), p.Swap())
+
+	ps := []Pair[string, int]{Of("b", 1), Of("a", 2), Of("a", 1)}
+	slices.SortFunc(ps, Compare)
+	assert.Equal(t, []Pair[string, int]{Of("a", 1), Of("a", 2), Of("b", 1)}, ps)
+
+	byKeyIgnoreCase := CompareFunc(func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	}, func(a, b int) int { return cmp.Compare(b, a) })
+	ps = []Pair[string, int]{Of("B", 1), Of("a", 1), Of("A", 2)}
+	slices.SortStableFunc(ps, byKeyIgnoreCase)
+	assert.Equal(t, []Pair[string, int]{Of("A", 2), Of("a", 1), Of("B", 1)}, ps)
+}
+
+func TestZip(t *testing.T) {
+	ps := Zip([]string{"a", "b", "c"}, []int{1, 2})
+	assert.Equal(t, []Pair[string, int]{Of("a", 1), Of("b", 2)}, ps)
+	keys, values := Unzip(ps)
+	assert.Equal(t, []string{"a", "b"}, keys)
+	assert.Equal(t, []int{1, 2}, values)
+
+	keys, values = Unzip[string, int](nil)
+	assert.Empty(t, keys)
+	assert.Empty(t, values)
+}
+
+func TestPair_JSON(t *testing.T) {
+	data, err := json.Marshal(map[string]Pair[string, int]{"p": Of("a", 1)})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"p":["a",1]}`, string(data))
+
+	var p Pair[string, int]
+	assert.NoError(t, json.Unmarshal([]byte(`["b", 2]`), &p))
+	assert.Equal(t, Of("b", 2), p)
+	assert.NoError(t, json.Unmarshal([]byte(`null`), &p))
+	assert.Equal(t, Of("b", 2), p)
+	assert.Error(t, json.Unmarshal([]byte(`["b"]`), &p))
+	assert.Error(t, json.Unmarshal([]byte(`[1, 2]`), &p))
+	assert.Error(t, json.Unmarshal([]byte(`{}`), &p))
+	assert.Equal(t, Of("b", 2), p)
+}
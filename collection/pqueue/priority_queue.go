@@ -0,0 +1,217 @@
+package pqueue
+
+import (
+	"cmp"
+	"errors"
+	"iter"
+
+	"github.com/hsiafan/go-utils/lang/optional"
+)
+
+// ErrorNotInQueue is the panic value when updating an element that is not in the queue.
+var ErrorNotInQueue = errors.New("pqueue: element of handle is not in queue")
+
+// Handle is a reference to an element in a PriorityQueue, it can be used to update or remove the element.
+type Handle[T any] struct {
+	value T
+	index int // index in heap, -1 if the element is not in queue
+}
+
+// Value returns the element value.
+func (h *Handle[T]) Value() T {
+	return h.value
+}
+
+// InQueue reports whether the element is still in the queue.
+func (h *Handle[T]) InQueue() bool {
+	return h.index >= 0
+}
+
+// PriorityQueue is a priority queue implemented by a binary heap, the least element is popped first.
+//
+// A PriorityQueue must be created by [New], [NewFunc], [FromSlice] or [FromSliceFunc], which set the order
+// of elements. The zero PriorityQueue has no order, and panics when the second element is pushed.
+type PriorityQueue[T any] struct {
+	heap []*Handle[T]
+	less func(a, b T) bool
+}
+
+// New creates a new PriorityQueue, with elements in natural order.
+func New[T cmp.Ordered]() *PriorityQueue[T] {
+	return NewFunc(cmp.Less[T])
+}
+
+// NewFunc creates a new PriorityQueue, with elements ordered by less func.
+func NewFunc[T any](less func(a, b T) bool) *PriorityQueue[T] {
+	return &PriorityQueue[T]{less: less}
+}
+
+// FromSlice creates a new PriorityQueue contains the values, with elements in natural order.
+// It takes O(n) time.
+func FromSlice[T cmp.Ordered](values []T) *PriorityQueue[T] {
+	return FromSliceFunc(values, cmp.Less[T])
+}
+
+// FromSliceFunc creates a new PriorityQueue contains the values, with elements ordered by less func.
+// It takes O(n) time.
+func FromSliceFunc[T any](values []T, less func(a, b T) bool) *PriorityQueue[T] {
+	q := NewFunc(less)
+	q.heap = make([]*Handle[T], len(values))
+	for i, v := range values {
+		q.heap[i] = &Handle[T]{value: v, index: i}
+	}
+	for i := len(q.heap)/2 - 1; i >= 0; i-- {
+		q.down(i)
+	}
+	return q
+}
+
+// Push adds an element, returns a handle to the element.
+func (q *PriorityQueue[T]) Push(v T) *Handle[T] {
+	h := &Handle[T]{value: v, index: len(q.heap)}
+	q.heap = append(q.heap, h)
+	q.up(h.index)
+	return h
+}
+
+// Pop removes and returns the least element.
+func (q *PriorityQueue[T]) Pop() optional.Optional[T] {
+	if len(q.heap) == 0 {
+		return optional.Empty[T]()
+	}
+	return optional.OfValue(q.removeAt(0).value)
+}
+
+// Peek returns the least element without removing it.
+func (q *PriorityQueue[T]) Peek() optional.Optional[T] {
+	if len(q.heap) == 0 {
+		return optional.Empty[T]()
+	}
+	return optional.OfValue(q.heap[0].value)
+}
+
+// Update sets a new value for the element referenced by handle, and restores the order.
+// It is usually used to implement decrease-key. It panics with [ErrorNotInQueue] if the element is not in this queue.
+func (q *PriorityQueue[T]) Update(h *Handle[T], v T) {
+	q.checkHandle(h)
+	h.value = v
+	q.fix(h.index)
+}
+
+// Fix restores the order after the element referenced by handle has changed, e.g. a field of pointer value.
+// It panics with [ErrorNotInQueue] if the element is not in this queue.
+func (q *PriorityQueue[T]) Fix(h *Handle[T]) {
+	q.checkHandle(h)
+	q.fix(h.index)
+}
+
+// Remove removes the element referenced by handle, returns false if the element is not in queue.
+func (q *PriorityQueue[T]) Remove(h *Handle[T]) bool {
+	if !h.InQueue() || h.index >= len(q.heap) || q.heap[h.index] != h {
+		return false
+	}
+	q.removeAt(h.index)
+	return true
+}
+
+// Drain returns a sequence which pops elements in priority order, until the queue is empty or the iteration stops.
+func (q *PriorityQueue[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for len(q.heap) > 0 {
+			if !yield(q.removeAt(0).value) {
+				break
+			}
+		}
+	}
+}
+
+// All returns all elements as a sequence, in unspecified order. The queue is not changed.
+func (q *PriorityQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, h := range q.heap {
+			if !yield(h.value) {
+				break
+			}
+		}
+	}
+}
+
+// Size returns the number of elements in the queue.
+func (q *PriorityQueue[T]) Size() int {
+	return len(q.heap)
+}
+
+// Clear removes all elements.
+func (q *PriorityQueue[T]) Clear() {
+	for _, h := range q.heap {
+		h.index = -1
+	}
+	clear(q.heap)
+	q.heap = q.heap[:0]
+}
+
+func (q *PriorityQueue[T]) checkHandle(h *Handle[T]) {
+	if !h.InQueue() || h.index >= len(q.heap) || q.heap[h.index] != h {
+		panic(ErrorNotInQueue)
+	}
+}
+
+func (q *PriorityQueue[T]) removeAt(i int) *Handle[T] {
+	h := q.heap[i]
+	last := len(q.heap) - 1
+	if i != last {
+		q.swap(i, last)
+	}
+	q.heap[last] = nil
+	q.heap = q.heap[:last]
+	if i != last {
+		q.fix(i)
+	}
+	h.index = -1
+	return h
+}
+
+func (q *PriorityQueue[T]) fix(i int) {
+	if !q.down(i) {
+		q.up(i)
+	}
+}
+
+func (q *PriorityQueue[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !q.less(q.heap[i].value, q.heap[parent].value) {
+			break
+		}
+		q.swap(i, parent)
+		i = parent
+	}
+}
+
+// down moves the element at i down, returns true if the element is moved.
+func (q *PriorityQueue[T]) down(i int) bool {
+	start := i
+	n := len(q.heap)
+	for {
+		left := 2*i + 1
+		if left >= n {
+			break
+		}
+		child := left
+		if right := left + 1; right < n && q.less(q.heap[right].value, q.heap[left].value) {
+			child = right
+		}
+		if !q.less(q.heap[child].value, q.heap[i].value) {
+			break
+		}
+		q.swap(i, child)
+		i = child
+	}
+	return i > start
+}
+
+func (q *PriorityQueue[T]) swap(i, j int) {
+	q.heap[i], q.heap[j] = q.heap[j], q.heap[i]
+	q.heap[i].index = i
+	q.heap[j].index = j
+}
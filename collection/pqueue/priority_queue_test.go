@@ -0,0 +1,86 @@
+package pqueue
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPriorityQueue(t *testing.T) {
+	q := New[int]()
+	assert.True(t, q.Peek().IsEmpty())
+	assert.True(t, q.Pop().IsEmpty())
+
+	q.Push(3)
+	q.Push(1)
+	q.Push(2)
+	assert.Equal(t, 3, q.Size())
+	assert.Equal(t, 1, q.Peek().Get())
+	assert.Equal(t, 1, q.Pop().Get())
+	assert.Equal(t, 2, q.Pop().Get())
+	assert.Equal(t, 3, q.Pop().Get())
+	assert.Equal(t, 0, q.Size())
+}
+
+func TestPriorityQueue_Random(t *testing.T) {
+	values := make([]int, 1000)
+	for i := range values {
+		values[i] = rand.IntN(100)
+	}
+	q := FromSlice(slices.Clone(values))
+	assert.ElementsMatch(t, values, slices.Collect(q.All()))
+	slices.Sort(values)
+	assert.Equal(t, values, slices.Collect(q.Drain()))
+	assert.Equal(t, 0, q.Size())
+}
+
+func TestPriorityQueue_Func(t *testing.T) {
+	q := FromSliceFunc([]string{"bb", "a", "ccc"}, func(a, b string) bool { return len(a) > len(b) })
+	q.Push("dddd")
+	assert.Equal(t, []string{"dddd", "ccc"}, slices.Collect(q.Drain())[:2])
+	assert.Equal(t, 0, q.Size())
+
+	q.Push("a")
+	q.Push("bb")
+	for v := range q.Drain() {
+		assert.Equal(t, "bb", v)
+		break
+	}
+	assert.Equal(t, 1, q.Size())
+}
+
+func TestPriorityQueue_Handle(t *testing.T) {
+	type task struct {
+		name     string
+		priority int
+	}
+	q := NewFunc(func(a, b *task) bool { return a.priority < b.priority })
+	ha := q.Push(&task{"a", 5})
+	hb := q.Push(&task{"b", 3})
+	hc := q.Push(&task{"c", 4})
+	assert.Equal(t, "b", q.Peek().Get().name)
+
+	// decrease key
+	q.Update(ha, &task{"a", 1})
+	assert.Equal(t, "a", q.Peek().Get().name)
+
+	// change through pointer, then fix
+	hb.Value().priority = 10
+	q.Fix(hb)
+
+	assert.True(t, q.Remove(hc))
+	assert.False(t, hc.InQueue())
+	assert.False(t, q.Remove(hc))
+	assert.PanicsWithValue(t, ErrorNotInQueue, func() { q.Fix(hc) })
+
+	assert.Equal(t, "a", q.Pop().Get().name)
+	assert.False(t, ha.InQueue())
+	assert.Equal(t, "b", q.Pop().Get().name)
+
+	hd := q.Push(&task{"d", 1})
+	q.Clear()
+	assert.False(t, hd.InQueue())
+	assert.Equal(t, 0, q.Size())
+}
@@ -0,0 +1,122 @@
+package collection
+
+import "iter"
+
+// SetView is a read-only view of a set.
+type SetView[T any] interface {
+	// Contains reports is given value exists in this set
+	Contains(v T) bool
+	// All returns all values in Set as a [iter.Seq].
+	All() iter.Seq[T]
+	// ToSlice return a slice contains the elements in the set.
+	ToSlice() []T
+	// Size returns the element count of set.
+	Size() int
+}
+
+// Set is a set of elements, implemented by set.Set, linkedset.Set and sortedset.Set.
+type Set[T any] interface {
+	SetView[T]
+	// Add adds new element to set
+	Add(v T)
+	// AddAll adds all values to set
+	AddAll(values ...T)
+	// Remove removes element from set if it exists.
+	Remove(v T)
+	// RemoveAll removes all elements from set.
+	RemoveAll(values ...T)
+	// Clear removes all elements from set.
+	Clear()
+}
+
+// Union returns a sequence of elements in one of sets.
+// The elements of s1 are yielded first, then the elements only in s2.
+func Union[T any](s1, s2 SetView[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range s1.All() {
+			if !yield(v) {
+				return
+			}
+		}
+		for v := range s2.All() {
+			if !s1.Contains(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Intersection returns a sequence of elements in both sets, in the order of s1.
+func Intersection[T any](s1, s2 SetView[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range s1.All() {
+			if s2.Contains(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Difference returns a sequence of elements in s1 but not in s2, in the order of s1.
+func Difference[T any](s1, s2 SetView[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range s1.All() {
+			if !s2.Contains(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// SymmetricDifference returns a sequence of elements in only one of sets.
+// The elements only in s1 are yielded first, then the elements only in s2.
+func SymmetricDifference[T any](s1, s2 SetView[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range Difference(s1, s2) {
+			if !yield(v) {
+				return
+			}
+		}
+		for v := range Difference(s2, s1) {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// IsSubsetOf reports whether all elements of s1 are in s2.
+func IsSubsetOf[T any](s1, s2 SetView[T]) bool {
+	if s1.Size() > s2.Size() {
+		return false
+	}
+	for v := range s1.All() {
+		if !s2.Contains(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSupersetOf reports whether all elements of s2 are in s1.
+func IsSupersetOf[T any](s1, s2 SetView[T]) bool {
+	return IsSubsetOf(s2, s1)
+}
+
+// IsDisjoint reports whether the two sets have no element in common.
+func IsDisjoint[T any](s1, s2 SetView[T]) bool {
+	if s1.Size() > s2.Size() {
+		s1, s2 = s2, s1
+	}
+	for v := range s1.All() {
+		if s2.Contains(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal reports whether the two sets contain the same elements.
+func Equal[T any](s1, s2 SetView[T]) bool {
+	return s1.Size() == s2.Size() && IsSubsetOf(s1, s2)
+}
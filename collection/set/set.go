@@ -2,13 +2,19 @@ package set
 
 import (
 	"iter"
+
+	"github.com/hsiafan/go-utils/collection"
 )
 
 type empty struct{}
 
 // Set is set implemented by map
+//
+// A Set is not safe for concurrent use, see syncset.Set for a concurrent-safe set.
 type Set[T comparable] map[T]empty
 
+var _ collection.Set[int] = Set[int]{}
+
 // New creates new set
 func New[T comparable](values ...T) Set[T] {
 	s := Set[T]{}
@@ -112,7 +118,7 @@ func (s Set[T]) Values() iter.Seq[T] {
 
 // ToSlice return a slice contains the elements in the set
 func (s Set[T]) ToSlice() []T {
-	slice := make([]T, len(s))
+	slice := make([]T, 0, len(s))
 	for v, _ := range s {
 		slice = append(slice, v)
 	}
@@ -124,32 +130,47 @@ func (s Set[T]) Size() int {
 	return len(s)
 }
 
+// Clear removes all elements from set.
+func (s Set[T]) Clear() {
+	clear(s)
+}
+
 // Union returns a new set contains elements in one of sets.
-func (s Set[T]) Union(s2 Set[T]) Set[T] {
-	r := make(Set[T])
-	r.AddSet(s)
-	r.AddSet(s2)
-	return s
+func (s Set[T]) Union(s2 collection.SetView[T]) Set[T] {
+	return Collect(collection.Union(s, s2))
 }
 
 // Intersection returns a new set contains element in both sets.
-func (s Set[T]) Intersection(s2 Set[T]) Set[T] {
-	r := make(Set[T])
-	for v := range s {
-		if s2.Contains(v) {
-			r.Add(v)
-		}
-	}
-	return r
+func (s Set[T]) Intersection(s2 collection.SetView[T]) Set[T] {
+	return Collect(collection.Intersection(s, s2))
 }
 
 // Difference returns a set contains elements in s but not in s2
-func (s Set[T]) Difference(s2 Set[T]) Set[T] {
-	r := make(Set[T])
-	for v := range s {
-		if !s2.Contains(v) {
-			r.Add(v)
-		}
-	}
-	return r
+func (s Set[T]) Difference(s2 collection.SetView[T]) Set[T] {
+	return Collect(collection.Difference(s, s2))
+}
+
+// SymmetricDifference returns a set contains elements in only one of sets.
+func (s Set[T]) SymmetricDifference(s2 collection.SetView[T]) Set[T] {
+	return Collect(collection.SymmetricDifference(s, s2))
+}
+
+// IsSubsetOf reports whether all elements of s are in s2.
+func (s Set[T]) IsSubsetOf(s2 collection.SetView[T]) bool {
+	return collection.IsSubsetOf(s, s2)
+}
+
+// IsSupersetOf reports whether all elements of s2 are in s.
+func (s Set[T]) IsSupersetOf(s2 collection.SetView[T]) bool {
+	return collection.IsSupersetOf(s, s2)
+}
+
+// IsDisjoint reports whether s and s2 have no element in common.
+func (s Set[T]) IsDisjoint(s2 collection.SetView[T]) bool {
+	return collection.IsDisjoint(s, s2)
+}
+
+// Equal reports whether s and s2 contain the same elements.
+func (s Set[T]) Equal(s2 collection.SetView[T]) bool {
+	return collection.Equal(s, s2)
 }
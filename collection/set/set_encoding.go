@@ -0,0 +1,120 @@
+package set
+
+import (
+	"cmp"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// MarshalJSON encodes the set as a JSON array. A nil set is encoded as null.
+// The order of elements is unspecified, convert the set to [Sorted] to get a deterministic order.
+func (s Set[T]) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(s.ToSlice())
+}
+
+// UnmarshalJSON decodes a JSON array into the set, replacing the existing elements. A JSON null is a no-op.
+func (s *Set[T]) UnmarshalJSON(data []byte) error {
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	if values == nil {
+		return nil
+	}
+	if *s == nil {
+		*s = NewWithSize[T](len(values))
+	} else {
+		clear(*s)
+	}
+	s.AddAll(values...)
+	return nil
+}
+
+// MarshalText encodes the set as text, the text form is the same as the JSON form.
+func (s Set[T]) MarshalText() ([]byte, error) {
+	return s.MarshalJSON()
+}
+
+// UnmarshalText decodes the set from text, the text form is the same as the JSON form.
+func (s *Set[T]) UnmarshalText(text []byte) error {
+	return s.UnmarshalJSON(text)
+}
+
+// Value implements [driver.Valuer], the set is stored as a JSON array. A nil set is stored as NULL.
+func (s Set[T]) Value() (driver.Value, error) {
+	if s == nil {
+		return nil, nil
+	}
+	data, err := s.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan implements [sql.Scanner], reads a set from JSON array stored as string or bytes. NULL is scanned as a nil set.
+func (s *Set[T]) Scan(src any) error {
+	switch src := src.(type) {
+	case nil:
+		*s = nil
+		return nil
+	case string:
+		return s.UnmarshalJSON([]byte(src))
+	case []byte:
+		return s.UnmarshalJSON(src)
+	default:
+		return fmt.Errorf("set: cannot scan %T into Set", src)
+	}
+}
+
+// Sorted is a [Set] of ordered elements, which encodes its elements in ascending order.
+// A Set can be converted to Sorted, e.g. set.Sorted[string](s), to get a deterministic JSON, text or SQL value.
+type Sorted[T cmp.Ordered] Set[T]
+
+// MarshalJSON encodes the set as a JSON array in ascending order. A nil set is encoded as null.
+func (s Sorted[T]) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+	values := Set[T](s).ToSlice()
+	slices.Sort(values)
+	return json.Marshal(values)
+}
+
+// UnmarshalJSON decodes a JSON array into the set, replacing the existing elements. A JSON null is a no-op.
+func (s *Sorted[T]) UnmarshalJSON(data []byte) error {
+	return (*Set[T])(s).UnmarshalJSON(data)
+}
+
+// MarshalText encodes the set as text, the text form is the same as the JSON form.
+func (s Sorted[T]) MarshalText() ([]byte, error) {
+	return s.MarshalJSON()
+}
+
+// UnmarshalText decodes the set from text, the text form is the same as the JSON form.
+func (s *Sorted[T]) UnmarshalText(text []byte) error {
+	return s.UnmarshalJSON(text)
+}
+
+// Value implements [driver.Valuer], the set is stored as a JSON array in ascending order.
+// A nil set is stored as NULL.
+func (s Sorted[T]) Value() (driver.Value, error) {
+	if s == nil {
+		return nil, nil
+	}
+	data, err := s.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan implements [sql.Scanner], reads a set from JSON array stored as string or bytes. NULL is scanned as a nil set.
+func (s *Sorted[T]) Scan(src any) error {
+	return (*Set[T])(s).Scan(src)
+}
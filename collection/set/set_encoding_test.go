@@ -0,0 +1,74 @@
+package set
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSet_JSON(t *testing.T) {
+	data, err := json.Marshal(New(1))
+	assert.NoError(t, err)
+	assert.Equal(t, "[1]", string(data))
+
+	data, err = json.Marshal(Sorted[string](New("c", "a", "b")))
+	assert.NoError(t, err)
+	assert.Equal(t, `["a","b","c"]`, string(data))
+
+	var nilSet Set[int]
+	data, err = json.Marshal(nilSet)
+	assert.NoError(t, err)
+	assert.Equal(t, "null", string(data))
+
+	type tags struct {
+		Ids  Set[int]       `json:"ids"`
+		Tags Sorted[string] `json:"tags"`
+	}
+	var v tags
+	err = json.Unmarshal([]byte(`{"ids":[3,1,3],"tags":["y","x"]}`), &v)
+	assert.NoError(t, err)
+	assert.Equal(t, New(1, 3), v.Ids)
+	assert.Equal(t, Sorted[string](New("x", "y")), v.Tags)
+
+	data, err = json.Marshal(v)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"tags":["x","y"]`)
+
+	s := New(5)
+	assert.NoError(t, json.Unmarshal([]byte(`[1,2]`), &s))
+	assert.Equal(t, New(1, 2), s)
+	assert.NoError(t, json.Unmarshal([]byte(`null`), &s))
+	assert.Equal(t, New(1, 2), s)
+	assert.Error(t, json.Unmarshal([]byte(`{"a":{}}`), &s))
+}
+
+func TestSet_Text(t *testing.T) {
+	text, err := Sorted[int](New(2, 1)).MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "[1,2]", string(text))
+
+	var s Set[int]
+	assert.NoError(t, s.UnmarshalText(text))
+	assert.Equal(t, New(1, 2), s)
+}
+
+func TestSet_SQL(t *testing.T) {
+	v, err := Sorted[string](New("b", "a")).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, `["a","b"]`, v)
+
+	var nilSet Set[string]
+	v, err = nilSet.Value()
+	assert.NoError(t, err)
+	assert.Nil(t, v)
+
+	var s Set[string]
+	assert.NoError(t, s.Scan(`["a","b"]`))
+	assert.Equal(t, New("a", "b"), s)
+	assert.NoError(t, s.Scan([]byte(`["c"]`)))
+	assert.Equal(t, New("c"), s)
+	assert.NoError(t, s.Scan(nil))
+	assert.Nil(t, s)
+	assert.Error(t, s.Scan(1))
+}
@@ -0,0 +1,25 @@
+package set
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSet_ToSlice(t *testing.T) {
+	s := New(1, 2, 3)
+	assert.ElementsMatch(t, []int{1, 2, 3}, s.ToSlice())
+}
+
+func TestSet_Algebra(t *testing.T) {
+	s1 := New(1, 2, 3)
+	s2 := New(3, 4)
+	assert.Equal(t, New(1, 2, 3, 4), s1.Union(s2))
+	assert.Equal(t, New(3), s1.Intersection(s2))
+	assert.Equal(t, New(1, 2), s1.Difference(s2))
+	assert.Equal(t, New(1, 2, 4), s1.SymmetricDifference(s2))
+	assert.True(t, New(1, 2).IsSubsetOf(s1))
+	assert.True(t, s1.IsSupersetOf(New(1, 2)))
+	assert.False(t, s1.IsDisjoint(s2))
+	assert.True(t, s1.Equal(New(3, 2, 1)))
+}
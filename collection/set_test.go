@@ -0,0 +1,44 @@
+package collection_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/hsiafan/go-utils/collection"
+	"github.com/hsiafan/go-utils/collection/linkedset"
+	"github.com/hsiafan/go-utils/collection/set"
+	"github.com/hsiafan/go-utils/collection/sortedset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetAlgebra(t *testing.T) {
+	s1 := linkedset.New(4, 3, 2, 1)
+	s2 := sortedset.New(3, 4, 5)
+	assert.Equal(t, []int{4, 3, 2, 1, 5}, slices.Collect(collection.Union[int](s1, s2)))
+	assert.Equal(t, []int{4, 3}, slices.Collect(collection.Intersection[int](s1, s2)))
+	assert.Equal(t, []int{2, 1}, slices.Collect(collection.Difference[int](s1, s2)))
+	assert.Equal(t, []int{2, 1, 5}, slices.Collect(collection.SymmetricDifference[int](s1, s2)))
+
+	s3 := set.New(1, 2)
+	assert.True(t, collection.IsSubsetOf[int](s3, s1))
+	assert.False(t, collection.IsSubsetOf[int](s1, s3))
+	assert.True(t, collection.IsSupersetOf[int](s1, s3))
+	assert.True(t, collection.IsDisjoint[int](s3, s2))
+	assert.False(t, collection.IsDisjoint[int](s1, s2))
+	assert.True(t, collection.Equal[int](set.New(1, 2, 3, 4), s1))
+	assert.False(t, collection.Equal[int](set.New(1, 2, 3, 5), s1))
+}
+
+func TestSet(t *testing.T) {
+	sets := []collection.Set[int]{set.New[int](), linkedset.New[int](), sortedset.New[int]()}
+	for _, s := range sets {
+		s.AddAll(1, 2, 3)
+		s.Add(4)
+		s.Remove(1)
+		s.RemoveAll(2, 5)
+		assert.Equal(t, 2, s.Size())
+		assert.ElementsMatch(t, []int{3, 4}, s.ToSlice())
+		s.Clear()
+		assert.Equal(t, 0, s.Size())
+	}
+}
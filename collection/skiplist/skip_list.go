@@ -0,0 +1,522 @@
+package skiplist
+
+import (
+	"cmp"
+	"iter"
+	"math"
+	"math/bits"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+
+	"github.com/hsiafan/go-utils/collection/pair"
+	"github.com/hsiafan/go-utils/lang/optional"
+)
+
+// maxLevel is the max level of nodes, enough for 2^64 entries with p = 1/4.
+const maxLevel = 32
+
+// latest is the version to read the latest state of the map.
+const latest = math.MaxUint64
+
+// minSweep is the least number of pending nodes to trigger a sweep.
+const minSweep = 16
+
+// entry is one version of the value of a key. Entries of a node are chained from the newest to the oldest.
+type entry[V any] struct {
+	v       V
+	ver     uint64
+	deleted bool
+	older   atomic.Pointer[entry[V]]
+}
+
+// node is one skip list node. The key and the length of next are immutable after the node is published.
+// A node is unlinked from the list only when no running iteration can see it.
+type node[K, V any] struct {
+	k       K
+	entries atomic.Pointer[entry[V]]
+	next    []atomic.Pointer[node[K, V]]
+
+	// accessed by writers only
+	pending  bool // in the pending list, for it has old versions or is deleted
+	unlinked bool
+}
+
+// at returns the entry visible at version ver, or nil if the key is absent at that version.
+func (n *node[K, V]) at(ver uint64) *entry[V] {
+	e := n.entries.Load()
+	for e != nil && e.ver > ver {
+		e = e.older.Load()
+	}
+	if e == nil || e.deleted {
+		return nil
+	}
+	return e
+}
+
+// Map is a concurrent-safe map that keeps keys in sorted order, implemented by a multi-version skip list.
+// Reads (Get, navigation and iteration) are lock-free and never block; writes are serialized by a mutex,
+// so Map is suitable for read-heavy workloads such as in-memory indexes, where many readers scan while a writer updates.
+// Most operations take expected O(log n) time.
+//
+// Each iteration over the sequences returned by [Map.All], [Map.Keys], [Map.Values] and [Map.Range] sees a consistent
+// snapshot of the map, taken when the iteration starts: keys and values added, updated or removed after that,
+// including by the iterating goroutine itself, are not visible to it. Old versions of entries are kept as long as
+// some running iteration may see them, so a long-running iteration retains the memory of entries changed meanwhile.
+// Other reads see the latest state of the map.
+//
+// A Map must be created by [New] or [NewFunc].
+type Map[K, V any] struct {
+	mu      sync.Mutex // serializes writers
+	head    *node[K, V]
+	level   atomic.Int32
+	size    atomic.Int64
+	version atomic.Uint64 // the last published version
+	compare func(K, K) int
+
+	// versions of running iterations, with reference counts
+	readersMu sync.Mutex
+	readers   map[uint64]int
+
+	// accessed by writers only
+	pending   []*node[K, V] // nodes may have versions no iteration can see
+	sweepSize int           // the number of pending nodes to trigger next sweep
+}
+
+// New creates a new skip list Map, with keys in natural order.
+func New[K cmp.Ordered, V any]() *Map[K, V] {
+	return NewFunc[K, V](cmp.Compare[K])
+}
+
+// NewFunc creates a new skip list Map, with keys ordered by compare func.
+// The compare func should return a negative number when a < b, a positive number when a > b and zero when a == b.
+func NewFunc[K, V any](compare func(a, b K) int) *Map[K, V] {
+	m := &Map[K, V]{
+		head:      &node[K, V]{next: make([]atomic.Pointer[node[K, V]], maxLevel)},
+		compare:   compare,
+		readers:   make(map[uint64]int),
+		sweepSize: minSweep,
+	}
+	m.level.Store(1)
+	return m
+}
+
+// Compare compares two keys by the order of this map.
+func (m *Map[K, V]) Compare(a, b K) int {
+	return m.compare(a, b)
+}
+
+// Contains returns true if key exists.
+func (m *Map[K, V]) Contains(k K) bool {
+	n := m.find(k)
+	return n != nil && n.at(latest) != nil
+}
+
+// Get returns value for key.
+func (m *Map[K, V]) Get(k K) optional.Optional[V] {
+	if n := m.find(k); n != nil {
+		if e := n.at(latest); e != nil {
+			return optional.OfValue(e.v)
+		}
+	}
+	return optional.Empty[V]()
+}
+
+// Put adds or sets value for key.
+func (m *Map[K, V]) Put(k K, v V) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.put(k, v)
+}
+
+// LoadOrStore returns the existing value for the key if present, and true.
+// Otherwise, it stores and returns the given value, and false.
+func (m *Map[K, V]) LoadOrStore(k K, v V) (V, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if n := m.find(k); n != nil {
+		if e := n.at(latest); e != nil {
+			return e.v, true
+		}
+	}
+	m.put(k, v)
+	return v, false
+}
+
+// put adds or sets value for key as a new version. It must be called with the lock held.
+func (m *Map[K, V]) put(k K, v V) {
+	var preds [maxLevel]*node[K, V]
+	ver := m.version.Load() + 1
+	e := &entry[V]{v: v, ver: ver}
+	n := m.findPreds(k, &preds)
+	if n == nil {
+		m.insert(k, e, &preds)
+		m.size.Add(1)
+		m.version.Store(ver)
+		m.collect(m.horizon())
+		return
+	}
+	if n.at(latest) == nil {
+		m.size.Add(1)
+	}
+	e.older.Store(n.entries.Load())
+	n.entries.Store(e)
+	m.version.Store(ver)
+	m.retire(n, &preds, m.horizon())
+}
+
+// insert links a new node after preds. The new node is fully built before it is published,
+// and published from bottom up, so readers always see a well-formed list.
+func (m *Map[K, V]) insert(k K, e *entry[V], preds *[maxLevel]*node[K, V]) {
+	level := randomLevel()
+	n := &node[K, V]{k: k, next: make([]atomic.Pointer[node[K, V]], level)}
+	n.entries.Store(e)
+	for i := 0; i < level; i++ {
+		n.next[i].Store(preds[i].next[i].Load())
+	}
+	for i := 0; i < level; i++ {
+		preds[i].next[i].Store(n)
+	}
+	if int32(level) > m.level.Load() {
+		m.level.Store(int32(level))
+	}
+}
+
+// Remove removes key.
+func (m *Map[K, V]) Remove(k K) {
+	m.LoadAndRemove(k)
+}
+
+// RemoveAll removes all keys.
+func (m *Map[K, V]) RemoveAll(keys ...K) {
+	for _, k := range keys {
+		m.Remove(k)
+	}
+}
+
+// LoadAndRemove removes key, returns the previous value if any.
+func (m *Map[K, V]) LoadAndRemove(k K) optional.Optional[V] {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var preds [maxLevel]*node[K, V]
+	n := m.findPreds(k, &preds)
+	if n == nil {
+		return optional.Empty[V]()
+	}
+	old := n.at(latest)
+	if old == nil {
+		return optional.Empty[V]()
+	}
+	// the key is removed by a deleted version, the node is unlinked when no iteration can see it
+	ver := m.version.Load() + 1
+	e := &entry[V]{ver: ver, deleted: true}
+	e.older.Store(n.entries.Load())
+	n.entries.Store(e)
+	m.size.Add(-1)
+	m.version.Store(ver)
+	m.retire(n, &preds, m.horizon())
+	return optional.OfValue(old.v)
+}
+
+// Floor returns the entry with the greatest key less than or equal to the given key.
+func (m *Map[K, V]) Floor(k K) optional.Optional[pair.Pair[K, V]] {
+	return m.floor(func(n *node[K, V]) bool { return m.compare(n.k, k) <= 0 })
+}
+
+// Ceiling returns the entry with the least key greater than or equal to the given key.
+func (m *Map[K, V]) Ceiling(k K) optional.Optional[pair.Pair[K, V]] {
+	return first(m.seek(k, true), latest)
+}
+
+// Lower returns the entry with the greatest key strictly less than the given key.
+func (m *Map[K, V]) Lower(k K) optional.Optional[pair.Pair[K, V]] {
+	return m.floor(func(n *node[K, V]) bool { return m.compare(n.k, k) < 0 })
+}
+
+// Higher returns the entry with the least key strictly greater than the given key.
+func (m *Map[K, V]) Higher(k K) optional.Optional[pair.Pair[K, V]] {
+	return first(m.seek(k, false), latest)
+}
+
+// Min returns the entry with the least key.
+func (m *Map[K, V]) Min() optional.Optional[pair.Pair[K, V]] {
+	return first(m.head.next[0].Load(), latest)
+}
+
+// Max returns the entry with the greatest key.
+func (m *Map[K, V]) Max() optional.Optional[pair.Pair[K, V]] {
+	return m.floor(func(*node[K, V]) bool { return true })
+}
+
+// All returns all key-value pairs as a sequence, in ascending order of keys.
+// The iteration sees a snapshot of the map taken when it starts, see [Map].
+func (m *Map[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.ascend(nil, func(k K, v V) bool {
+			return yield(k, v)
+		})
+	}
+}
+
+// Keys returns all keys as a sequence, in ascending order.
+// The iteration sees a snapshot of the map taken when it starts, see [Map].
+func (m *Map[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		m.ascend(nil, func(k K, _ V) bool {
+			return yield(k)
+		})
+	}
+}
+
+// Values returns all values as a sequence, in ascending order of keys.
+// The iteration sees a snapshot of the map taken when it starts, see [Map].
+func (m *Map[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		m.ascend(nil, func(_ K, v V) bool {
+			return yield(v)
+		})
+	}
+}
+
+// Range returns the key-value pairs with from <= key < to as a sequence, in ascending order of keys.
+// The iteration sees a snapshot of the map taken when it starts, see [Map].
+func (m *Map[K, V]) Range(from, to K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.ascend(&from, func(k K, v V) bool {
+			return m.compare(k, to) < 0 && yield(k, v)
+		})
+	}
+}
+
+// Size returns the size of the map.
+func (m *Map[K, V]) Size() int {
+	return int(m.size.Load())
+}
+
+// Clear clears the map.
+func (m *Map[K, V]) Clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ver := m.version.Load() + 1
+	for n := m.head.next[0].Load(); n != nil; n = n.next[0].Load() {
+		if n.at(latest) == nil {
+			continue
+		}
+		e := &entry[V]{ver: ver, deleted: true}
+		e.older.Store(n.entries.Load())
+		n.entries.Store(e)
+		if !n.pending {
+			n.pending = true
+			m.pending = append(m.pending, n)
+		}
+	}
+	m.size.Store(0)
+	m.version.Store(ver)
+	m.sweep(m.horizon())
+}
+
+// acquire registers a running iteration, returns the version it sees.
+func (m *Map[K, V]) acquire() uint64 {
+	m.readersMu.Lock()
+	defer m.readersMu.Unlock()
+	ver := m.version.Load()
+	m.readers[ver]++
+	return ver
+}
+
+// release unregisters a running iteration of the version.
+func (m *Map[K, V]) release(ver uint64) {
+	m.readersMu.Lock()
+	defer m.readersMu.Unlock()
+	if m.readers[ver]--; m.readers[ver] == 0 {
+		delete(m.readers, ver)
+	}
+}
+
+// horizon returns the oldest version any running iteration sees, or the last version if no iteration is running.
+// Versions of entries older than the one visible at the horizon are not visible to any iteration.
+func (m *Map[K, V]) horizon() uint64 {
+	m.readersMu.Lock()
+	defer m.readersMu.Unlock()
+	h := m.version.Load()
+	for ver := range m.readers {
+		h = min(h, ver)
+	}
+	return h
+}
+
+// retire drops the versions of the node no iteration can see, and unlinks the node if it is deleted and
+// no iteration can see it. Otherwise the node is added to the pending list, and checked again by later sweeps.
+// It must be called by writers, with the lock held and preds of the node.
+func (m *Map[K, V]) retire(n *node[K, V], preds *[maxLevel]*node[K, V], horizon uint64) {
+	switch trim(n, horizon) {
+	case obsolete:
+		m.unlink(n, preds)
+	case retained:
+		if !n.pending {
+			n.pending = true
+			m.pending = append(m.pending, n)
+		}
+	}
+	m.collect(horizon)
+}
+
+// collect sweeps when there are enough pending nodes, or when no iteration is running, then all of them can be retired.
+// It must be called by writers, with the lock held.
+func (m *Map[K, V]) collect(horizon uint64) {
+	if len(m.pending) >= m.sweepSize || len(m.pending) > 0 && horizon == m.version.Load() {
+		m.sweep(horizon)
+	}
+}
+
+// sweep retires all pending nodes. It must be called by writers, with the lock held.
+func (m *Map[K, V]) sweep(horizon uint64) {
+	var preds [maxLevel]*node[K, V]
+	remained := m.pending[:0]
+	for _, n := range m.pending {
+		if n.unlinked {
+			continue
+		}
+		switch trim(n, horizon) {
+		case obsolete:
+			m.findPreds(n.k, &preds)
+			m.unlink(n, &preds)
+			continue
+		case retained:
+			remained = append(remained, n)
+			continue
+		}
+		n.pending = false
+	}
+	clear(m.pending[len(remained):])
+	m.pending = remained
+	m.sweepSize = max(2*len(remained), minSweep)
+}
+
+// unlink removes the node from the list, from top down. The links of the node are kept,
+// so readers currently standing on it can still move forward.
+func (m *Map[K, V]) unlink(n *node[K, V], preds *[maxLevel]*node[K, V]) {
+	for i := len(n.next) - 1; i >= 0; i-- {
+		preds[i].next[i].Store(n.next[i].Load())
+	}
+	n.unlinked = true
+	n.pending = false
+}
+
+type trimResult int
+
+const (
+	clean    trimResult = iota // the node has only one live version
+	retained                   // the node has versions some iteration may see
+	obsolete                   // the node is deleted and no iteration can see it
+)
+
+// trim drops the versions of the node older than the one visible at horizon.
+func trim[K, V any](n *node[K, V], horizon uint64) trimResult {
+	newest := n.entries.Load()
+	e := newest
+	for e != nil && e.ver > horizon {
+		e = e.older.Load()
+	}
+	if e != nil {
+		e.older.Store(nil)
+	}
+	switch {
+	case e == newest && newest.deleted:
+		return obsolete
+	case newest.older.Load() == nil && !newest.deleted:
+		return clean
+	default:
+		return retained
+	}
+}
+
+// findPreds finds the last node with key less than k at each level, and returns the node with key k if exists.
+// It must be called by writers, with the lock held.
+func (m *Map[K, V]) findPreds(k K, preds *[maxLevel]*node[K, V]) *node[K, V] {
+	x := m.head
+	for i := maxLevel - 1; i >= 0; i-- {
+		for next := x.next[i].Load(); next != nil && m.compare(next.k, k) < 0; next = x.next[i].Load() {
+			x = next
+		}
+		preds[i] = x
+	}
+	if next := x.next[0].Load(); next != nil && m.compare(next.k, k) == 0 {
+		return next
+	}
+	return nil
+}
+
+// find returns the node with key k, or nil. The node may be deleted.
+func (m *Map[K, V]) find(k K) *node[K, V] {
+	if n := m.seek(k, true); n != nil && m.compare(n.k, k) == 0 {
+		return n
+	}
+	return nil
+}
+
+// seek returns the first node with key greater than (or equal to, if inclusive) k, or nil. The node may be deleted.
+func (m *Map[K, V]) seek(k K, inclusive bool) *node[K, V] {
+	x := m.head
+	for i := int(m.level.Load()) - 1; i >= 0; i-- {
+		for next := x.next[i].Load(); next != nil; next = x.next[i].Load() {
+			if c := m.compare(next.k, k); c > 0 || c == 0 && inclusive {
+				break
+			}
+			x = next
+		}
+	}
+	return x.next[0].Load()
+}
+
+// floor returns the latest entry of the last node satisfies pred, which should be true for a prefix of the list.
+func (m *Map[K, V]) floor(pred func(*node[K, V]) bool) optional.Optional[pair.Pair[K, V]] {
+	for {
+		x := m.head
+		for i := int(m.level.Load()) - 1; i >= 0; i-- {
+			for next := x.next[i].Load(); next != nil && pred(next); next = x.next[i].Load() {
+				x = next
+			}
+		}
+		if x == m.head {
+			return optional.Empty[pair.Pair[K, V]]()
+		}
+		if e := x.at(latest); e != nil {
+			return optional.OfValue(pair.Of(x.k, e.v))
+		}
+		// the node is deleted, search for the nodes before it
+		last := x
+		pred = func(n *node[K, V]) bool { return m.compare(n.k, last.k) < 0 }
+	}
+}
+
+// first returns the entry of the first node from n visible at version ver.
+func first[K, V any](n *node[K, V], ver uint64) optional.Optional[pair.Pair[K, V]] {
+	for ; n != nil; n = n.next[0].Load() {
+		if e := n.at(ver); e != nil {
+			return optional.OfValue(pair.Of(n.k, e.v))
+		}
+	}
+	return optional.Empty[pair.Pair[K, V]]()
+}
+
+// ascend visits the entries visible at a snapshot in ascending order, starting from the first key >= from,
+// or the least key if from is nil, until visit returns false.
+func (m *Map[K, V]) ascend(from *K, visit func(K, V) bool) {
+	ver := m.acquire()
+	defer m.release(ver)
+	n := m.head.next[0].Load()
+	if from != nil {
+		n = m.seek(*from, true)
+	}
+	for ; n != nil; n = n.next[0].Load() {
+		if e := n.at(ver); e != nil && !visit(n.k, e.v) {
+			return
+		}
+	}
+}
+
+// randomLevel returns a random level in [1, maxLevel], with p = 1/4 to go up one level.
+func randomLevel() int {
+	return min(bits.TrailingZeros64(rand.Uint64())/2+1, maxLevel)
+}
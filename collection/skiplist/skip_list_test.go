@@ -0,0 +1,321 @@
+package skiplist
+
+import (
+	"iter"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hsiafan/go-utils/collection/pair"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSkipList(t *testing.T) {
+	m := New[int, string]()
+	m.Put(3, "3")
+	m.Put(1, "1")
+	m.Put(4, "4")
+	m.Put(2, "2")
+	m.Put(1, "one")
+	assert.Equal(t, 4, m.Size())
+	assert.Equal(t, "one", m.Get(1).Get())
+	assert.True(t, m.Get(5).IsEmpty())
+	assert.True(t, m.Contains(2))
+
+	v, loaded := m.LoadOrStore(2, "two")
+	assert.True(t, loaded)
+	assert.Equal(t, "2", v)
+	v, loaded = m.LoadOrStore(6, "6")
+	assert.False(t, loaded)
+	assert.Equal(t, "6", v)
+
+	assert.Equal(t, "6", m.LoadAndRemove(6).Get())
+	assert.True(t, m.LoadAndRemove(6).IsEmpty())
+	m.RemoveAll(2, 7)
+	assert.Equal(t, 3, m.Size())
+	assert.Equal(t, []int{1, 3, 4}, slices.Collect(m.Keys()))
+	assert.Equal(t, []string{"one", "3", "4"}, slices.Collect(m.Values()))
+
+	m.Clear()
+	assert.Equal(t, 0, m.Size())
+	assert.Empty(t, slices.Collect(m.Keys()))
+}
+
+func TestSkipList_Navigation(t *testing.T) {
+	m := New[int, int]()
+	assert.True(t, m.Min().IsEmpty())
+	assert.True(t, m.Max().IsEmpty())
+	for i := 10; i <= 50; i += 10 {
+		m.Put(i, i*10)
+	}
+	assert.Equal(t, pair.Of(30, 300), m.Floor(30).Get())
+	assert.Equal(t, pair.Of(30, 300), m.Floor(35).Get())
+	assert.True(t, m.Floor(5).IsEmpty())
+	assert.Equal(t, pair.Of(30, 300), m.Ceiling(30).Get())
+	assert.Equal(t, pair.Of(40, 400), m.Ceiling(35).Get())
+	assert.True(t, m.Ceiling(55).IsEmpty())
+	assert.Equal(t, pair.Of(20, 200), m.Lower(30).Get())
+	assert.True(t, m.Lower(10).IsEmpty())
+	assert.Equal(t, pair.Of(40, 400), m.Higher(30).Get())
+	assert.True(t, m.Higher(50).IsEmpty())
+	assert.Equal(t, pair.Of(10, 100), m.Min().Get())
+	assert.Equal(t, pair.Of(50, 500), m.Max().Get())
+
+	var keys []int
+	for k := range m.Range(20, 40) {
+		keys = append(keys, k)
+	}
+	assert.Equal(t, []int{20, 30}, keys)
+	keys = keys[:0]
+	for k := range m.Range(15, 100) {
+		keys = append(keys, k)
+		if k == 40 {
+			break
+		}
+	}
+	assert.Equal(t, []int{20, 30, 40}, keys)
+}
+
+func TestSkipList_Random(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	m := NewFunc[int, int](func(a, b int) int { return b - a }) // descending
+	model := map[int]int{}
+	for i := 0; i < 5000; i++ {
+		k := r.IntN(500)
+		if r.IntN(3) == 0 {
+			m.Remove(k)
+			delete(model, k)
+		} else {
+			m.Put(k, i)
+			model[k] = i
+		}
+	}
+	assert.Equal(t, len(model), m.Size())
+	var keys []int
+	for k := range model {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	slices.Reverse(keys)
+	assert.Equal(t, keys, slices.Collect(m.Keys()))
+	for k, v := range m.All() {
+		assert.Equal(t, model[k], v)
+	}
+}
+
+func TestSkipList_Snapshot(t *testing.T) {
+	m := New[int, int]()
+	for i := 0; i < 100; i++ {
+		m.Put(i, i)
+	}
+	var keys, values []int
+	for k, v := range m.All() {
+		keys = append(keys, k)
+		values = append(values, v)
+		// modifications after the iteration starts are not visible to it
+		m.Remove(k + 1)
+		m.Put(k, -k)
+		m.Put(k+1000, k)
+	}
+	expected := make([]int, 100)
+	for i := range expected {
+		expected[i] = i
+	}
+	assert.Equal(t, expected, keys)
+	assert.Equal(t, expected, values)
+	assert.Equal(t, 200, m.Size())
+	assert.Equal(t, -50, m.Get(50).Get())
+	assert.Equal(t, pair.Of(1099, 99), m.Max().Get())
+
+	// an iteration started inside another one sees the changes made before it starts
+	var outer, inner []int
+	for k := range m.Range(0, 1000) {
+		outer = append(outer, k)
+		for k := range m.Range(0, 2000) {
+			inner = append(inner, k)
+		}
+		m.Clear()
+	}
+	assert.Equal(t, expected, outer)
+	assert.Len(t, inner, 200)
+	assert.Equal(t, 0, m.Size())
+	assert.Empty(t, slices.Collect(m.Keys()))
+}
+
+func TestSkipList_NavigationSkipsDeleted(t *testing.T) {
+	m := New[int, int]()
+	for i := 0; i < 10; i++ {
+		m.Put(i, i)
+	}
+	// hold a snapshot, so the removed nodes are kept in the list
+	next, stop := iter.Pull(m.Keys())
+	next()
+	m.RemoveAll(3, 4, 5, 9, 0)
+	assert.False(t, m.Contains(4))
+	assert.True(t, m.Get(4).IsEmpty())
+	assert.Equal(t, pair.Of(2, 2), m.Floor(5).Get())
+	assert.Equal(t, pair.Of(2, 2), m.Lower(6).Get())
+	assert.Equal(t, pair.Of(6, 6), m.Ceiling(3).Get())
+	assert.Equal(t, pair.Of(6, 6), m.Higher(2).Get())
+	assert.Equal(t, pair.Of(1, 1), m.Min().Get())
+	assert.Equal(t, pair.Of(8, 8), m.Max().Get())
+	v, loaded := m.LoadOrStore(4, 40)
+	assert.False(t, loaded)
+	assert.Equal(t, 40, v)
+	assert.Equal(t, 6, m.Size())
+
+	// the snapshot still sees removed keys, but not the re-added value
+	var keys []int
+	for k, ok := next(); ok; k, ok = next() {
+		keys = append(keys, k)
+	}
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9}, keys)
+	stop()
+}
+
+func TestSkipList_DropOldVersions(t *testing.T) {
+	m := New[int, int]()
+	for i := 0; i < 100; i++ {
+		m.Put(i, i)
+	}
+	next, stop := iter.Pull(m.Keys())
+	next()
+	for i := 0; i < 100; i++ {
+		m.Put(i, -i)
+		m.Remove(i)
+	}
+	assert.Equal(t, 100, countNodes(m))
+	stop()
+
+	// after the iteration ends, old versions and removed nodes are dropped by the next write
+	m.Put(1000, 1000)
+	assert.Equal(t, 1, countNodes(m))
+	assert.Empty(t, m.pending)
+	for n := m.head.next[0].Load(); n != nil; n = n.next[0].Load() {
+		assert.Nil(t, n.entries.Load().older.Load())
+	}
+
+	// without running iterations, nothing is retained
+	for i := 0; i < 100; i++ {
+		m.Put(i, i)
+		m.Put(i, -i)
+		m.Remove(i)
+	}
+	assert.Equal(t, 1, countNodes(m))
+	assert.Empty(t, m.pending)
+}
+
+// countNodes returns the number of nodes linked in the list, include the deleted ones.
+func countNodes[K, V any](m *Map[K, V]) int {
+	count := 0
+	for n := m.head.next[0].Load(); n != nil; n = n.next[0].Load() {
+		count++
+	}
+	return count
+}
+
+// TestSkipList_ConcurrentSnapshots runs readers scanning while a writer updates, should be run with -race.
+// The writer updates a clock key before each operation, so every snapshot must equal the state after the operations
+// before the clock, optionally with the operation at the clock applied.
+func TestSkipList_ConcurrentSnapshots(t *testing.T) {
+	const keys, ops = 200, 20000
+	const clock = -1
+	type op struct {
+		k      int
+		remove bool
+	}
+	r := rand.New(rand.NewPCG(1, 2))
+	log := make([]op, ops)
+	for i := range log {
+		log[i] = op{k: r.IntN(keys), remove: r.IntN(3) == 0}
+	}
+	apply := func(state map[int]int, i int) {
+		if log[i].remove {
+			delete(state, log[i].k)
+		} else {
+			state[log[i].k] = i
+		}
+	}
+
+	m := New[int, int]()
+	var stop atomic.Bool
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer stop.Store(true)
+		for i, o := range log {
+			m.Put(clock, i)
+			if o.remove {
+				m.Remove(o.k)
+			} else {
+				m.Put(o.k, i)
+			}
+		}
+	}()
+
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for !stop.Load() {
+				snapshot := map[int]int{}
+				last, c := clock-1, -1
+				for k, v := range m.All() {
+					assert.Less(t, last, k)
+					last = k
+					if k == clock {
+						c = v
+					} else {
+						snapshot[k] = v
+					}
+				}
+				if c < 0 {
+					assert.Empty(t, snapshot)
+					continue
+				}
+				state := map[int]int{}
+				for i := 0; i < c; i++ {
+					apply(state, i)
+				}
+				if !maps.Equal(state, snapshot) {
+					apply(state, c)
+					assert.Equal(t, state, snapshot)
+				}
+			}
+		}()
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for !stop.Load() {
+			k := rand.IntN(keys)
+			m.Get(k)
+			m.Floor(k)
+			m.Ceiling(k)
+			for range m.Range(k, k+10) {
+			}
+		}
+	}()
+	wg.Wait()
+	assert.Equal(t, ops-1, m.Get(clock).Get())
+}
+
+func BenchmarkSkipList_Get(b *testing.B) {
+	m := New[int, int]()
+	for i := 0; i < 100000; i++ {
+		m.Put(i, i)
+	}
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			m.Get(i % 100000)
+			i++
+		}
+	})
+}
@@ -0,0 +1,314 @@
+package sortedset
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+
+	"github.com/hsiafan/go-utils/collection"
+	"github.com/hsiafan/go-utils/collection/pair"
+	"github.com/hsiafan/go-utils/collection/treemap"
+	"github.com/hsiafan/go-utils/lang/optional"
+)
+
+type empty struct{}
+
+// Set is a set that keeps elements in sorted order, implemented by [treemap.Map].
+//
+// The set algebra methods merge the two sets in linear time if the other set is also a sorted Set in the same order,
+// otherwise they look up the elements in the other set.
+type Set[T any] treemap.Map[T, empty]
+
+var _ collection.Set[int] = (*Set[int])(nil)
+
+// New creates a new SortedSet, with elements in natural order.
+func New[T cmp.Ordered](values ...T) *Set[T] {
+	return NewFunc(cmp.Compare[T], values...)
+}
+
+// NewFunc creates a new SortedSet, with elements ordered by compare func.
+func NewFunc[T any](compare func(a, b T) int, values ...T) *Set[T] {
+	m := treemap.NewFunc[T, empty](compare)
+	for _, v := range values {
+		m.Put(v, empty{})
+	}
+	return (*Set[T])(m)
+}
+
+// Collect collects items into a SortedSet, with elements in natural order.
+// If items of seq are in strictly ascending order, the set is built in linear time.
+func Collect[T cmp.Ordered](seq iter.Seq[T]) *Set[T] {
+	return CollectFunc(seq, cmp.Compare[T])
+}
+
+// CollectFunc collects items into a SortedSet, with elements ordered by compare func.
+// If items of seq are in strictly ascending order, the set is built in linear time.
+func CollectFunc[T any](seq iter.Seq[T], compare func(a, b T) int) *Set[T] {
+	return (*Set[T])(treemap.CollectFunc(withEmpty(seq), compare))
+}
+
+// Contains reports is given value exists in this set
+func (s *Set[T]) Contains(v T) bool {
+	return s.m().Contains(v)
+}
+
+// Add adds new element to set
+func (s *Set[T]) Add(v T) {
+	s.m().Put(v, empty{})
+}
+
+// AddAll adds all values to set
+func (s *Set[T]) AddAll(values ...T) {
+	for _, v := range values {
+		s.m().Put(v, empty{})
+	}
+}
+
+// AddSet adds all values to set
+func (s *Set[T]) AddSet(s2 *Set[T]) {
+	for v := range s2.All() {
+		s.m().Put(v, empty{})
+	}
+}
+
+// Remove removes element from set if it exists.
+func (s *Set[T]) Remove(v T) {
+	s.m().Remove(v)
+}
+
+// RemoveAll removes all elements from set.
+func (s *Set[T]) RemoveAll(values ...T) {
+	for _, v := range values {
+		s.m().Remove(v)
+	}
+}
+
+// Copy return a new set with same elements as the original one.
+func (s *Set[T]) Copy() *Set[T] {
+	return (*Set[T])(s.m().Copy())
+}
+
+// All returns all values in Set as a [iter.Seq], in ascending order.
+func (s *Set[T]) All() iter.Seq[T] {
+	return s.m().Keys()
+}
+
+// Backward returns all values in Set as a [iter.Seq], in descending order.
+func (s *Set[T]) Backward() iter.Seq[T] {
+	return keys(s.m().Backward())
+}
+
+// Range returns the values with from <= v < to as a [iter.Seq], in ascending order.
+func (s *Set[T]) Range(from, to T) iter.Seq[T] {
+	return keys(s.m().Range(from, to))
+}
+
+// Head returns a new set contains the elements strictly less than to.
+// The returned set is a copy, not a view, changes to either set do not affect the other.
+func (s *Set[T]) Head(to T) *Set[T] {
+	return s.fromSorted(keys(s.m().RangeTo(to)))
+}
+
+// Tail returns a new set contains the elements greater than or equal to from.
+// The returned set is a copy, not a view, changes to either set do not affect the other.
+func (s *Set[T]) Tail(from T) *Set[T] {
+	return s.fromSorted(keys(s.m().RangeFrom(from)))
+}
+
+// Floor returns the greatest element less than or equal to v.
+func (s *Set[T]) Floor(v T) optional.Optional[T] {
+	return key(s.m().Floor(v))
+}
+
+// Ceiling returns the least element greater than or equal to v.
+func (s *Set[T]) Ceiling(v T) optional.Optional[T] {
+	return key(s.m().Ceiling(v))
+}
+
+// Lower returns the greatest element strictly less than v.
+func (s *Set[T]) Lower(v T) optional.Optional[T] {
+	return key(s.m().Lower(v))
+}
+
+// Higher returns the least element strictly greater than v.
+func (s *Set[T]) Higher(v T) optional.Optional[T] {
+	return key(s.m().Higher(v))
+}
+
+// Min returns the least element.
+func (s *Set[T]) Min() optional.Optional[T] {
+	return key(s.m().Min())
+}
+
+// Max returns the greatest element.
+func (s *Set[T]) Max() optional.Optional[T] {
+	return key(s.m().Max())
+}
+
+// PopMin removes and returns the least element.
+func (s *Set[T]) PopMin() optional.Optional[T] {
+	return key(s.m().PopMin())
+}
+
+// PopMax removes and returns the greatest element.
+func (s *Set[T]) PopMax() optional.Optional[T] {
+	return key(s.m().PopMax())
+}
+
+// ToSlice return a slice contains the elements in the set, in ascending order.
+func (s *Set[T]) ToSlice() []T {
+	slice := make([]T, 0, s.Size())
+	for v := range s.All() {
+		slice = append(slice, v)
+	}
+	return slice
+}
+
+// Size returns the element count of set.
+func (s *Set[T]) Size() int {
+	return s.m().Size()
+}
+
+// Clear removes all elements from set.
+func (s *Set[T]) Clear() {
+	s.m().Clear()
+}
+
+// Union returns a new set contains elements in one of sets.
+func (s *Set[T]) Union(s2 collection.SetView[T]) *Set[T] {
+	if b, ok := s.sameOrder(s2); ok {
+		return s.merge(b, true, true, true)
+	}
+	return s.fromUnsorted(collection.Union(s, s2))
+}
+
+// Intersection returns a new set contains element in both sets.
+func (s *Set[T]) Intersection(s2 collection.SetView[T]) *Set[T] {
+	if b, ok := s.sameOrder(s2); ok {
+		return s.merge(b, false, true, false)
+	}
+	return s.fromUnsorted(collection.Intersection(s, s2))
+}
+
+// Difference returns a set contains elements in s but not in s2
+func (s *Set[T]) Difference(s2 collection.SetView[T]) *Set[T] {
+	if b, ok := s.sameOrder(s2); ok {
+		return s.merge(b, true, false, false)
+	}
+	return s.fromUnsorted(collection.Difference(s, s2))
+}
+
+// SymmetricDifference returns a set contains elements in only one of the sets.
+func (s *Set[T]) SymmetricDifference(s2 collection.SetView[T]) *Set[T] {
+	if b, ok := s.sameOrder(s2); ok {
+		return s.merge(b, true, false, true)
+	}
+	return s.fromUnsorted(collection.SymmetricDifference(s, s2))
+}
+
+// IsSubsetOf reports whether all elements of s are in s2.
+func (s *Set[T]) IsSubsetOf(s2 collection.SetView[T]) bool {
+	return collection.IsSubsetOf(s, s2)
+}
+
+// IsSupersetOf reports whether all elements of s2 are in s.
+func (s *Set[T]) IsSupersetOf(s2 collection.SetView[T]) bool {
+	return collection.IsSupersetOf(s, s2)
+}
+
+// IsDisjoint reports whether s and s2 have no element in common.
+func (s *Set[T]) IsDisjoint(s2 collection.SetView[T]) bool {
+	return collection.IsDisjoint(s, s2)
+}
+
+// Equal reports whether s and s2 contain the same elements.
+func (s *Set[T]) Equal(s2 collection.SetView[T]) bool {
+	return collection.Equal(s, s2)
+}
+
+// sameOrder returns the elements of s2 if it is a sorted Set, and its elements are in strictly ascending order of s.
+func (s *Set[T]) sameOrder(s2 collection.SetView[T]) ([]T, bool) {
+	other, ok := s2.(*Set[T])
+	if !ok {
+		return nil, false
+	}
+	b := other.ToSlice()
+	for i := 1; i < len(b); i++ {
+		if s.m().Compare(b[i-1], b[i]) >= 0 {
+			return nil, false
+		}
+	}
+	return b, true
+}
+
+// merge walks the two sorted sets side by side, and collects elements only in s, in both sets,
+// and only in b according to the flags. The elements of b must be in ascending order of s.
+func (s *Set[T]) merge(b []T, onlyLeft, both, onlyRight bool) *Set[T] {
+	a := s.ToSlice()
+	return s.fromSorted(func(yield func(T) bool) {
+		i, j := 0, 0
+		for i < len(a) || j < len(b) {
+			var c int
+			if i == len(a) {
+				c = 1
+			} else if j == len(b) {
+				c = -1
+			} else {
+				c = s.m().Compare(a[i], b[j])
+			}
+			var v T
+			var accept bool
+			if c < 0 {
+				v, accept = a[i], onlyLeft
+				i++
+			} else if c > 0 {
+				v, accept = b[j], onlyRight
+				j++
+			} else {
+				v, accept = a[i], both
+				i++
+				j++
+			}
+			if accept && !yield(v) {
+				return
+			}
+		}
+	})
+}
+
+func (s *Set[T]) fromSorted(seq iter.Seq[T]) *Set[T] {
+	return CollectFunc(seq, s.m().CompareFunc())
+}
+
+func (s *Set[T]) fromUnsorted(seq iter.Seq[T]) *Set[T] {
+	return NewFunc(s.m().CompareFunc(), slices.Collect(seq)...)
+}
+
+func (s *Set[T]) m() *treemap.Map[T, empty] {
+	return (*treemap.Map[T, empty])(s)
+}
+
+func withEmpty[T any](seq iter.Seq[T]) iter.Seq2[T, empty] {
+	return func(yield func(T, empty) bool) {
+		for v := range seq {
+			if !yield(v, empty{}) {
+				break
+			}
+		}
+	}
+}
+
+func keys[T any](seq iter.Seq2[T, empty]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range seq {
+			if !yield(v) {
+				break
+			}
+		}
+	}
+}
+
+func key[T any](o optional.Optional[pair.Pair[T, empty]]) optional.Optional[T] {
+	p, ok := o.Unwrap()
+	return optional.Of(p.Key(), ok)
+}
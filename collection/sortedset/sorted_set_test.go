@@ -0,0 +1,103 @@
+package sortedset
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hsiafan/go-utils/collection/set"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortedSet(t *testing.T) {
+	set := New(3, 1, 2)
+	assert.Equal(t, 3, set.Size())
+	assert.Equal(t, []int{1, 2, 3}, slices.Collect(set.All()))
+	assert.Equal(t, []int{3, 2, 1}, slices.Collect(set.Backward()))
+	set.Remove(2)
+	assert.Equal(t, []int{1, 3}, set.ToSlice())
+
+	set.AddAll(5, 4)
+	set.AddSet(New(0, 1))
+	assert.True(t, set.Contains(0))
+	assert.Equal(t, []int{0, 1, 3, 4, 5}, set.ToSlice())
+
+	set.RemoveAll(0, 5)
+	assert.Equal(t, []int{1, 3, 4}, set.ToSlice())
+
+	copied := set.Copy()
+	set.Clear()
+	assert.Equal(t, 0, set.Size())
+	assert.Equal(t, []int{1, 3, 4}, copied.ToSlice())
+}
+
+func TestSortedSet_Func(t *testing.T) {
+	set := NewFunc(func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	}, "b", "A", "a", "C")
+	assert.Equal(t, []string{"A", "b", "C"}, set.ToSlice())
+
+	set2 := CollectFunc(slices.Values([]string{"B", "d"}), set.m().Compare)
+	assert.Equal(t, []string{"A", "C"}, set.Difference(set2).ToSlice())
+}
+
+func TestSortedSet_Navigation(t *testing.T) {
+	set := New(10, 20, 30, 40)
+	assert.Equal(t, 20, set.Floor(25).Get())
+	assert.Equal(t, 30, set.Ceiling(25).Get())
+	assert.Equal(t, 10, set.Lower(20).Get())
+	assert.Equal(t, 30, set.Higher(20).Get())
+	assert.True(t, set.Lower(10).IsEmpty())
+	assert.True(t, set.Higher(40).IsEmpty())
+	assert.Equal(t, 10, set.Min().Get())
+	assert.Equal(t, 40, set.Max().Get())
+
+	assert.Equal(t, []int{20, 30}, slices.Collect(set.Range(15, 40)))
+	assert.Equal(t, []int{10, 20}, set.Head(30).ToSlice())
+	assert.Equal(t, []int{30, 40}, set.Tail(30).ToSlice())
+	assert.Equal(t, 0, set.Head(5).Size())
+	assert.Equal(t, 0, set.Tail(45).Size())
+
+	head := set.Head(30)
+	head.Add(0)
+	set.Remove(20)
+	assert.Equal(t, []int{0, 10, 20}, head.ToSlice())
+	assert.Equal(t, []int{10, 30, 40}, set.ToSlice())
+
+	assert.Equal(t, 10, set.PopMin().Get())
+	assert.Equal(t, 40, set.PopMax().Get())
+	assert.Equal(t, []int{30}, set.ToSlice())
+}
+
+func TestSortedSet_Algebra(t *testing.T) {
+	s1 := New(1, 2, 3, 4)
+	s2 := New(3, 4, 5)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, s1.Union(s2).ToSlice())
+	assert.Equal(t, []int{3, 4}, s1.Intersection(s2).ToSlice())
+	assert.Equal(t, []int{1, 2}, s1.Difference(s2).ToSlice())
+	assert.Equal(t, []int{5}, s2.Difference(s1).ToSlice())
+	assert.Equal(t, []int{1, 2, 5}, s1.SymmetricDifference(s2).ToSlice())
+	assert.Equal(t, 0, s1.Intersection(New[int]()).Size())
+
+	// result sets are mutable as usual
+	u := s1.Union(s2)
+	u.Add(0)
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5}, u.ToSlice())
+
+	// sets in other order or of other types are looked up
+	desc := NewFunc(func(a, b int) int { return b - a }, 3, 4, 5)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, s1.Union(desc).ToSlice())
+	assert.Equal(t, []int{3, 4}, s1.Intersection(desc).ToSlice())
+	assert.Equal(t, []int{5}, desc.Difference(s1).ToSlice())
+	assert.Equal(t, []int{5, 2, 1}, desc.SymmetricDifference(s1).ToSlice())
+	hashed := set.New(3, 4, 5)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, s1.Union(hashed).ToSlice())
+	assert.Equal(t, []int{3, 4}, s1.Intersection(hashed).ToSlice())
+	assert.Equal(t, []int{1, 2}, s1.Difference(hashed).ToSlice())
+	assert.Equal(t, []int{1, 2, 5}, s1.SymmetricDifference(hashed).ToSlice())
+}
+
+func TestCollect(t *testing.T) {
+	set := Collect(slices.Values([]int{3, 1, 2, 1}))
+	assert.Equal(t, []int{1, 2, 3}, set.ToSlice())
+}
@@ -0,0 +1,254 @@
+package syncmap
+
+import (
+	"hash/maphash"
+	"iter"
+	"math/bits"
+	"runtime"
+	"sync"
+
+	"github.com/hsiafan/go-utils/lang/optional"
+)
+
+// shard is one lock-striped part of ShardedMap
+type shard[K comparable, V any] struct {
+	mu sync.RWMutex
+	m  map[K]V
+	_  [64]byte // avoid false sharing between shards
+}
+
+// ShardedMap is a concurrent-safe map, the keys are hashed into lock-striped shards, each guarded by a RWMutex.
+// It is suitable for write-heavy workloads under high contention, where a single lock around a map is a bottleneck.
+//
+// The method names are the same as linkedmap.Map, so they can be swapped easily.
+// A ShardedMap must be created by [NewSharded].
+type ShardedMap[K comparable, V any] struct {
+	seed   maphash.Seed
+	shards []shard[K, V]
+	mask   uint64
+}
+
+// NewSharded creates a new ShardedMap with given count of shards, the count is rounded up to a power of two.
+// If shardCount <= 0, a default count base on GOMAXPROCS is used.
+func NewSharded[K comparable, V any](shardCount int) *ShardedMap[K, V] {
+	if shardCount <= 0 {
+		shardCount = runtime.GOMAXPROCS(0) * 4
+	}
+	shardCount = 1 << bits.Len(uint(shardCount-1))
+	shards := make([]shard[K, V], shardCount)
+	for i := range shards {
+		shards[i].m = make(map[K]V)
+	}
+	return &ShardedMap[K, V]{
+		seed:   maphash.MakeSeed(),
+		shards: shards,
+		mask:   uint64(shardCount - 1),
+	}
+}
+
+func (m *ShardedMap[K, V]) shard(k K) *shard[K, V] {
+	return &m.shards[maphash.Comparable(m.seed, k)&m.mask]
+}
+
+// Contains returns true if key exists.
+func (m *ShardedMap[K, V]) Contains(k K) bool {
+	s := m.shard(k)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.m[k]
+	return ok
+}
+
+// Get returns value for key.
+func (m *ShardedMap[K, V]) Get(k K) optional.Optional[V] {
+	s := m.shard(k)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	v, ok := s.m[k]
+	return optional.Of(v, ok)
+}
+
+// Put adds or sets value for key.
+func (m *ShardedMap[K, V]) Put(k K, v V) {
+	s := m.shard(k)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.m[k] = v
+}
+
+// LoadOrStore returns the existing value for the key if present, and true.
+// Otherwise, it stores and returns the given value, and false.
+func (m *ShardedMap[K, V]) LoadOrStore(k K, v V) (V, bool) {
+	s := m.shard(k)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if actual, ok := s.m[k]; ok {
+		return actual, true
+	}
+	s.m[k] = v
+	return v, false
+}
+
+// AddIfAbsent add key-value to map if key not exists.
+// It returns the value for this key.
+func (m *ShardedMap[K, V]) AddIfAbsent(k K, v V) V {
+	actual, _ := m.LoadOrStore(k, v)
+	return actual
+}
+
+// ComputeIfAbsent add key-value to map if key not exists, the value is computed by compute func.
+// It returns the value for this key.
+//
+// The compute func is called at most once for a key, while holding the lock of the shard,
+// so it must not access this map.
+func (m *ShardedMap[K, V]) ComputeIfAbsent(k K, compute func(K) V) V {
+	s := m.shard(k)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if v, ok := s.m[k]; ok {
+		return v
+	}
+	v := compute(k)
+	s.m[k] = v
+	return v
+}
+
+// Compute computes a new value for key atomically, from the old value and whether it is present.
+// If compute returns false as the second value, the key is removed. It returns the new value for key.
+//
+// The compute func is called while holding the lock of the shard, so it must not access this map.
+func (m *ShardedMap[K, V]) Compute(k K, compute func(old V, present bool) (V, bool)) optional.Optional[V] {
+	s := m.shard(k)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	old, present := s.m[k]
+	v, keep := compute(old, present)
+	if !keep {
+		delete(s.m, k)
+		return optional.Empty[V]()
+	}
+	s.m[k] = v
+	return optional.OfValue(v)
+}
+
+// Remove removes key.
+func (m *ShardedMap[K, V]) Remove(k K) {
+	m.LoadAndRemove(k)
+}
+
+// RemoveAll removes all keys.
+func (m *ShardedMap[K, V]) RemoveAll(keys ...K) {
+	for _, k := range keys {
+		m.Remove(k)
+	}
+}
+
+// LoadAndRemove removes key, returns the previous value if any.
+func (m *ShardedMap[K, V]) LoadAndRemove(k K) optional.Optional[V] {
+	s := m.shard(k)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.m[k]
+	if ok {
+		delete(s.m, k)
+	}
+	return optional.Of(v, ok)
+}
+
+// All returns all key-value pairs as a sequence, in unspecified order.
+//
+// Each shard is copied under its lock before visiting, so the sequence is consistent within each shard,
+// but not across shards. The map can be modified while iterating. Use [ShardedMap.Snapshot] for
+// a consistent copy.
+func (m *ShardedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for i := range m.shards {
+			s := &m.shards[i]
+			s.mu.RLock()
+			keys := make([]K, 0, len(s.m))
+			values := make([]V, 0, len(s.m))
+			for k, v := range s.m {
+				keys = append(keys, k)
+				values = append(values, v)
+			}
+			s.mu.RUnlock()
+			for j, k := range keys {
+				if !yield(k, values[j]) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Keys returns all keys as a sequence, in unspecified order. See [ShardedMap.All] for the consistency.
+func (m *ShardedMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range m.All() {
+			if !yield(k) {
+				break
+			}
+		}
+	}
+}
+
+// Values returns all values as a sequence, in unspecified order. See [ShardedMap.All] for the consistency.
+func (m *ShardedMap[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range m.All() {
+			if !yield(v) {
+				break
+			}
+		}
+	}
+}
+
+// Snapshot returns a consistent copy of the map as a builtin map. All shards are locked while copying.
+func (m *ShardedMap[K, V]) Snapshot() map[K]V {
+	m.rLockAll()
+	defer m.rUnlockAll()
+	size := 0
+	for i := range m.shards {
+		size += len(m.shards[i].m)
+	}
+	snapshot := make(map[K]V, size)
+	for i := range m.shards {
+		for k, v := range m.shards[i].m {
+			snapshot[k] = v
+		}
+	}
+	return snapshot
+}
+
+// Size returns the size of the map. All shards are locked while counting.
+func (m *ShardedMap[K, V]) Size() int {
+	m.rLockAll()
+	defer m.rUnlockAll()
+	size := 0
+	for i := range m.shards {
+		size += len(m.shards[i].m)
+	}
+	return size
+}
+
+// Clear clears the map.
+func (m *ShardedMap[K, V]) Clear() {
+	for i := range m.shards {
+		s := &m.shards[i]
+		s.mu.Lock()
+		clear(s.m)
+		s.mu.Unlock()
+	}
+}
+
+func (m *ShardedMap[K, V]) rLockAll() {
+	for i := range m.shards {
+		m.shards[i].mu.RLock()
+	}
+}
+
+func (m *ShardedMap[K, V]) rUnlockAll() {
+	for i := range m.shards {
+		m.shards[i].mu.RUnlock()
+	}
+}
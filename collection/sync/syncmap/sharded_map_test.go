@@ -0,0 +1,86 @@
+package syncmap
+
+import (
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShardedMap(t *testing.T) {
+	m := NewSharded[string, int](3)
+	assert.Equal(t, 4, len(m.shards))
+
+	m.Put("1", 1)
+	m.Put("2", 2)
+	m.Put("1", 10)
+	assert.Equal(t, 2, m.Size())
+	assert.Equal(t, 10, m.Get("1").Get())
+	assert.True(t, m.Get("3").IsEmpty())
+	assert.True(t, m.Contains("2"))
+
+	v, loaded := m.LoadOrStore("2", 20)
+	assert.True(t, loaded)
+	assert.Equal(t, 2, v)
+	assert.Equal(t, 3, m.AddIfAbsent("3", 3))
+	assert.Equal(t, 3, m.ComputeIfAbsent("3", func(string) int { return 30 }))
+	assert.Equal(t, 4, m.ComputeIfAbsent("4", func(string) int { return 4 }))
+
+	assert.Equal(t, 4, m.LoadAndRemove("4").Get())
+	m.RemoveAll("3", "5")
+	assert.Equal(t, map[string]int{"1": 10, "2": 2}, m.Snapshot())
+	keys := slices.Collect(m.Keys())
+	slices.Sort(keys)
+	assert.Equal(t, []string{"1", "2"}, keys)
+	assert.ElementsMatch(t, []int{10, 2}, slices.Collect(m.Values()))
+
+	m.Clear()
+	assert.Equal(t, 0, m.Size())
+}
+
+func TestShardedMap_Compute(t *testing.T) {
+	m := NewSharded[string, int](0)
+	increment := func(old int, present bool) (int, bool) {
+		return old + 1, true
+	}
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				m.Compute("counter", increment)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 8000, m.Get("counter").Get())
+
+	r := m.Compute("counter", func(old int, present bool) (int, bool) {
+		return 0, false
+	})
+	assert.True(t, r.IsEmpty())
+	assert.False(t, m.Contains("counter"))
+}
+
+func TestShardedMap_ModifyWhileIterating(t *testing.T) {
+	m := NewSharded[int, int](4)
+	for i := 0; i < 100; i++ {
+		m.Put(i, i)
+	}
+	for k := range m.Keys() {
+		m.Remove(k)
+	}
+	assert.Equal(t, 0, m.Size())
+}
+
+func BenchmarkShardedMap_ReadHeavy(b *testing.B) {
+	m := NewSharded[string, int](0)
+	benchmarkMap(b, 1, func(k string) { m.Get(k) }, m.Put)
+}
+
+func BenchmarkShardedMap_WriteHeavy(b *testing.B) {
+	m := NewSharded[string, int](0)
+	benchmarkMap(b, 50, func(k string) { m.Get(k) }, m.Put)
+}
@@ -0,0 +1,163 @@
+package syncmap
+
+import (
+	"iter"
+	"sync"
+	"sync/atomic"
+
+	"github.com/hsiafan/go-utils/lang/optional"
+)
+
+// Map is a concurrent-safe map, implemented by [sync.Map].
+// It is optimized for read-heavy workloads, and for goroutines operate on disjoint sets of keys.
+// For write-heavy workloads under high contention, consider [ShardedMap].
+//
+// The zero Map is empty and ready for use. A Map must not be copied after first use.
+type Map[K comparable, V any] struct {
+	m    sync.Map
+	size atomic.Int64
+}
+
+// New creates a new concurrent-safe Map.
+func New[K comparable, V any]() *Map[K, V] {
+	return &Map[K, V]{}
+}
+
+// Contains returns true if key exists.
+func (m *Map[K, V]) Contains(k K) bool {
+	_, ok := m.m.Load(k)
+	return ok
+}
+
+// Get returns value for key.
+func (m *Map[K, V]) Get(k K) optional.Optional[V] {
+	v, ok := m.m.Load(k)
+	if !ok {
+		return optional.Empty[V]()
+	}
+	value, _ := v.(V) // a nil interface value fails the assertion, the zero value is the same nil
+	return optional.OfValue(value)
+}
+
+// Put adds or sets value for key.
+func (m *Map[K, V]) Put(k K, v V) {
+	if _, loaded := m.m.Swap(k, v); !loaded {
+		m.size.Add(1)
+	}
+}
+
+// LoadOrStore returns the existing value for the key if present, and true.
+// Otherwise, it stores and returns the given value, and false.
+func (m *Map[K, V]) LoadOrStore(k K, v V) (V, bool) {
+	actual, loaded := m.m.LoadOrStore(k, v)
+	if !loaded {
+		m.size.Add(1)
+	}
+	value, _ := actual.(V)
+	return value, loaded
+}
+
+// AddIfAbsent add key-value to map if key not exists.
+// It returns the value for this key.
+func (m *Map[K, V]) AddIfAbsent(k K, v V) V {
+	actual, _ := m.LoadOrStore(k, v)
+	return actual
+}
+
+// ComputeIfAbsent add key-value to map if key not exists, the value is computed by compute func.
+// It returns the value for this key.
+//
+// Only one computed value is stored and returned to all callers, but when multi goroutines call this method
+// with the same absent key at the same time, the compute func may be called more than once.
+func (m *Map[K, V]) ComputeIfAbsent(k K, compute func(K) V) V {
+	if v, ok := m.m.Load(k); ok {
+		value, _ := v.(V)
+		return value
+	}
+	actual, _ := m.LoadOrStore(k, compute(k))
+	return actual
+}
+
+// Remove removes key.
+func (m *Map[K, V]) Remove(k K) {
+	m.LoadAndRemove(k)
+}
+
+// RemoveAll removes all keys.
+func (m *Map[K, V]) RemoveAll(keys ...K) {
+	for _, k := range keys {
+		m.Remove(k)
+	}
+}
+
+// LoadAndRemove removes key, returns the previous value if any.
+func (m *Map[K, V]) LoadAndRemove(k K) optional.Optional[V] {
+	v, loaded := m.m.LoadAndDelete(k)
+	if !loaded {
+		return optional.Empty[V]()
+	}
+	m.size.Add(-1)
+	value, _ := v.(V)
+	return optional.OfValue(value)
+}
+
+// All returns all key-value pairs as a sequence, in unspecified order.
+//
+// The sequence does not correspond to any consistent snapshot of the map: each key is visited at most once,
+// but the key-values added or removed concurrently may or may not be visited. Use [ShardedMap.Snapshot] for a
+// point-in-time copy.
+func (m *Map[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.m.Range(func(k, v any) bool {
+			key, _ := k.(K)
+			value, _ := v.(V)
+			return yield(key, value)
+		})
+	}
+}
+
+// Keys returns all keys as a sequence, in unspecified order. See [Map.All] for the consistency.
+func (m *Map[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		m.m.Range(func(k, _ any) bool {
+			key, _ := k.(K)
+			return yield(key)
+		})
+	}
+}
+
+// Values returns all values as a sequence, in unspecified order. See [Map.All] for the consistency.
+func (m *Map[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		m.m.Range(func(_, v any) bool {
+			value, _ := v.(V)
+			return yield(value)
+		})
+	}
+}
+
+// Snapshot returns a copy of the map as a builtin map.
+//
+// The copy is weakly consistent, not a point-in-time snapshot: it is built by iterating the map, so under concurrent
+// writes it may mix states from before and after a write. Keys present and unchanged for the whole copying are
+// always included with their values; keys added, updated or removed concurrently may or may not be reflected.
+// Without concurrent writes, the copy is exact. Use [ShardedMap.Snapshot] when a point-in-time copy is required.
+func (m *Map[K, V]) Snapshot() map[K]V {
+	s := make(map[K]V, m.Size())
+	for k, v := range m.All() {
+		s[k] = v
+	}
+	return s
+}
+
+// Size returns the size of the map. The result may be stale when there are concurrent writes.
+func (m *Map[K, V]) Size() int {
+	return max(int(m.size.Load()), 0)
+}
+
+// Clear clears the map.
+func (m *Map[K, V]) Clear() {
+	for k := range m.Keys() {
+		m.Remove(k)
+	}
+}
@@ -0,0 +1,200 @@
+package syncmap
+
+import (
+	"io"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncMap(t *testing.T) {
+	m := New[string, int]()
+	m.Put("1", 1)
+	m.Put("2", 2)
+	m.Put("1", 10)
+	assert.Equal(t, 2, m.Size())
+	assert.Equal(t, 10, m.Get("1").Get())
+	assert.True(t, m.Get("3").IsEmpty())
+
+	v, loaded := m.LoadOrStore("2", 20)
+	assert.True(t, loaded)
+	assert.Equal(t, 2, v)
+	assert.Equal(t, 3, m.AddIfAbsent("3", 3))
+	assert.Equal(t, 3, m.Size())
+
+	assert.Equal(t, 3, m.LoadAndRemove("3").Get())
+	assert.True(t, m.LoadAndRemove("3").IsEmpty())
+	m.RemoveAll("1", "4")
+	assert.Equal(t, map[string]int{"2": 2}, m.Snapshot())
+	assert.Equal(t, []string{"2"}, slices.Collect(m.Keys()))
+	assert.Equal(t, []int{2}, slices.Collect(m.Values()))
+
+	m.Clear()
+	assert.Equal(t, 0, m.Size())
+	assert.False(t, m.Contains("2"))
+}
+
+func TestSyncMap_NilInterfaceValue(t *testing.T) {
+	var m Map[any, error]
+	m.Put("a", nil)
+	m.Put(nil, io.EOF)
+	assert.True(t, m.Get("a").IsPresent())
+	assert.Nil(t, m.Get("a").Get())
+	v, loaded := m.LoadOrStore("a", io.EOF)
+	assert.True(t, loaded)
+	assert.Nil(t, v)
+	assert.Nil(t, m.ComputeIfAbsent("a", func(any) error { return io.EOF }))
+	assert.Equal(t, map[any]error{"a": nil, nil: io.EOF}, m.Snapshot())
+	assert.ElementsMatch(t, []any{"a", nil}, slices.Collect(m.Keys()))
+	assert.ElementsMatch(t, []error{nil, io.EOF}, slices.Collect(m.Values()))
+	assert.True(t, m.LoadAndRemove("a").IsPresent())
+	assert.Equal(t, 1, m.Size())
+}
+
+func TestSyncMap_ComputeIfAbsent(t *testing.T) {
+	var m Map[int, int]
+	var wg sync.WaitGroup
+	var results [16]int
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = m.ComputeIfAbsent(1, func(k int) int { return i })
+		}()
+	}
+	wg.Wait()
+	for _, r := range results {
+		assert.Equal(t, results[0], r)
+	}
+	assert.Equal(t, 1, m.Size())
+}
+
+func TestSyncMap_Concurrent(t *testing.T) {
+	var m Map[int, int]
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				m.Put(g*1000+i, i)
+				if i%2 == 0 {
+					m.Remove(g*1000 + i)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 4000, m.Size())
+	assert.Equal(t, 4000, len(m.Snapshot()))
+}
+
+func TestSyncMap_SnapshotWeaklyConsistent(t *testing.T) {
+	var m Map[int, int]
+	// even keys are stable, odd keys are updated and removed concurrently
+	for i := 0; i < 100; i++ {
+		m.Put(i, i)
+	}
+	var stop atomic.Bool
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; !stop.Load(); i++ {
+			k := i%50*2 + 1
+			if i%3 == 0 {
+				m.Remove(k)
+			} else {
+				m.Put(k, -k)
+			}
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		snapshot := m.Snapshot()
+		for k := 0; k < 100; k += 2 {
+			assert.Equal(t, k, snapshot[k])
+		}
+		for k, v := range snapshot {
+			if k%2 == 1 {
+				assert.Contains(t, []int{k, -k}, v)
+			}
+		}
+	}
+	stop.Store(true)
+	wg.Wait()
+
+	// without concurrent writes the copy is exact
+	expected := map[int]int{}
+	for k, v := range m.All() {
+		expected[k] = v
+	}
+	assert.Equal(t, expected, m.Snapshot())
+	assert.Equal(t, m.Size(), len(expected))
+}
+
+// mutexMap is a plain map guarded by a mutex, for benchmarks.
+type mutexMap[K comparable, V any] struct {
+	mu sync.RWMutex
+	m  map[K]V
+}
+
+func (m *mutexMap[K, V]) Get(k K) (V, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	v, ok := m.m[k]
+	return v, ok
+}
+
+func (m *mutexMap[K, V]) Put(k K, v V) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.m[k] = v
+}
+
+const benchKeys = 1024
+
+func benchmarkMap(b *testing.B, writePercent int, get func(string), put func(string, int)) {
+	keys := make([]string, benchKeys)
+	for i := range keys {
+		keys[i] = strconv.Itoa(i)
+		put(keys[i], i)
+	}
+	var seq atomic.Int64
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := int(seq.Add(1)) * 7919
+		for pb.Next() {
+			k := keys[i%benchKeys]
+			if i%100 < writePercent {
+				put(k, i)
+			} else {
+				get(k)
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkSyncMap_ReadHeavy(b *testing.B) {
+	m := New[string, int]()
+	benchmarkMap(b, 1, func(k string) { m.Get(k) }, m.Put)
+}
+
+func BenchmarkMutexMap_ReadHeavy(b *testing.B) {
+	m := &mutexMap[string, int]{m: map[string]int{}}
+	benchmarkMap(b, 1, func(k string) { m.Get(k) }, m.Put)
+}
+
+func BenchmarkSyncMap_WriteHeavy(b *testing.B) {
+	m := New[string, int]()
+	benchmarkMap(b, 50, func(k string) { m.Get(k) }, m.Put)
+}
+
+func BenchmarkMutexMap_WriteHeavy(b *testing.B) {
+	m := &mutexMap[string, int]{m: map[string]int{}}
+	benchmarkMap(b, 50, func(k string) { m.Get(k) }, m.Put)
+}
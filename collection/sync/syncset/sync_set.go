@@ -0,0 +1,90 @@
+package syncset
+
+import (
+	"iter"
+
+	"github.com/hsiafan/go-utils/collection"
+	"github.com/hsiafan/go-utils/collection/sync/syncmap"
+)
+
+type empty struct{}
+
+// Set is a concurrent-safe set, implemented by [syncmap.Map].
+//
+// The zero Set is empty and ready for use. A Set must not be copied after first use.
+type Set[T comparable] struct {
+	m syncmap.Map[T, empty]
+}
+
+var _ collection.Set[int] = (*Set[int])(nil)
+
+// New creates a new concurrent-safe Set.
+func New[T comparable](values ...T) *Set[T] {
+	s := &Set[T]{}
+	s.AddAll(values...)
+	return s
+}
+
+// Contains reports is given value exists in this set
+func (s *Set[T]) Contains(v T) bool {
+	return s.m.Contains(v)
+}
+
+// Add adds new element to set
+func (s *Set[T]) Add(v T) {
+	s.m.Put(v, empty{})
+}
+
+// AddIfAbsent adds new element to set if it not exists, returns true if the element is added.
+func (s *Set[T]) AddIfAbsent(v T) bool {
+	_, loaded := s.m.LoadOrStore(v, empty{})
+	return !loaded
+}
+
+// AddAll adds all values to set
+func (s *Set[T]) AddAll(values ...T) {
+	for _, v := range values {
+		s.m.Put(v, empty{})
+	}
+}
+
+// Remove removes element from set if it exists.
+func (s *Set[T]) Remove(v T) {
+	s.m.Remove(v)
+}
+
+// RemoveIfPresent removes element from set, returns true if the element existed.
+func (s *Set[T]) RemoveIfPresent(v T) bool {
+	return s.m.LoadAndRemove(v).IsPresent()
+}
+
+// RemoveAll removes all elements from set.
+func (s *Set[T]) RemoveAll(values ...T) {
+	for _, v := range values {
+		s.m.Remove(v)
+	}
+}
+
+// All returns all values in Set as a [iter.Seq]. See [syncmap.Map.All] for the consistency.
+func (s *Set[T]) All() iter.Seq[T] {
+	return s.m.Keys()
+}
+
+// ToSlice return a slice contains the elements in the set.
+func (s *Set[T]) ToSlice() []T {
+	slice := make([]T, 0, s.Size())
+	for v := range s.All() {
+		slice = append(slice, v)
+	}
+	return slice
+}
+
+// Size returns the element count of set. The result may be stale when there are concurrent writes.
+func (s *Set[T]) Size() int {
+	return s.m.Size()
+}
+
+// Clear removes all elements from set.
+func (s *Set[T]) Clear() {
+	s.m.Clear()
+}
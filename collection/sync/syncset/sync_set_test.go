@@ -0,0 +1,46 @@
+package syncset
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncSet(t *testing.T) {
+	s := New(1, 2, 3)
+	assert.Equal(t, 3, s.Size())
+	assert.True(t, s.Contains(1))
+	assert.ElementsMatch(t, []int{1, 2, 3}, s.ToSlice())
+
+	assert.False(t, s.AddIfAbsent(1))
+	assert.True(t, s.AddIfAbsent(4))
+	assert.True(t, s.RemoveIfPresent(4))
+	assert.False(t, s.RemoveIfPresent(4))
+
+	s.RemoveAll(1, 2)
+	assert.ElementsMatch(t, []int{3}, s.ToSlice())
+	s.Clear()
+	assert.Equal(t, 0, s.Size())
+}
+
+func TestSyncSet_AddIfAbsent(t *testing.T) {
+	var s Set[int]
+	var added atomic.Int32
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				if s.AddIfAbsent(i) {
+					added.Add(1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(100), added.Load())
+	assert.Equal(t, 100, s.Size())
+}
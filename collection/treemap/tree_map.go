@@ -0,0 +1,599 @@
+package treemap
+
+import (
+	"cmp"
+	"errors"
+	"iter"
+
+	"github.com/hsiafan/go-utils/collection/pair"
+	"github.com/hsiafan/go-utils/lang/optional"
+)
+
+// ErrorConcurrentModification is the panic value when the map is structurally modified during iteration,
+// other than removing the entry currently being visited.
+var ErrorConcurrentModification = errors.New("treemap: concurrent modification during iteration")
+
+// node is one AVL tree node
+type node[K, V any] struct {
+	k      K
+	v      V
+	left   *node[K, V]
+	right  *node[K, V]
+	height int
+	size   int
+}
+
+// Map is a map that keeps keys in sorted order, implemented by an AVL tree.
+// Most operations, include rank and select operations, take O(log n) time.
+//
+// Iteration over the sequences returned by Map tolerates removing the entry currently being visited,
+// and setting value of existing keys. Any other structural modification (adding new keys, removing other keys,
+// clearing the map) during iteration will cause the iteration panics with [ErrorConcurrentModification].
+type Map[K, V any] struct {
+	root     *node[K, V]
+	compare  func(K, K) int
+	modCount int
+}
+
+// New creates a new TreeMap, with keys in natural order.
+func New[K cmp.Ordered, V any]() *Map[K, V] {
+	return NewFunc[K, V](cmp.Compare[K])
+}
+
+// NewFunc creates a new TreeMap, with keys ordered by compare func.
+// The compare func should return a negative number when a < b, a positive number when a > b and zero when a == b.
+func NewFunc[K, V any](compare func(a, b K) int) *Map[K, V] {
+	return &Map[K, V]{compare: compare}
+}
+
+// Collect collects key-values into a new TreeMap, with keys in natural order.
+// If keys of seq are in strictly ascending order, the map is built in linear time.
+func Collect[K cmp.Ordered, V any](seq iter.Seq2[K, V]) *Map[K, V] {
+	return CollectFunc(seq, cmp.Compare[K])
+}
+
+// CollectFunc collects key-values into a new TreeMap, with keys ordered by compare func.
+// If keys of seq are in strictly ascending order, the map is built in linear time.
+func CollectFunc[K, V any](seq iter.Seq2[K, V], compare func(a, b K) int) *Map[K, V] {
+	m := NewFunc[K, V](compare)
+	var nodes []node[K, V]
+	sorted := true
+	for k, v := range seq {
+		if sorted && len(nodes) > 0 && compare(nodes[len(nodes)-1].k, k) >= 0 {
+			sorted = false
+		}
+		nodes = append(nodes, node[K, V]{k: k, v: v})
+	}
+	if sorted {
+		m.root = buildTree(nodes)
+		return m
+	}
+	for _, n := range nodes {
+		m.Put(n.k, n.v)
+	}
+	return m
+}
+
+// buildTree builds a balanced tree from sorted nodes.
+func buildTree[K, V any](nodes []node[K, V]) *node[K, V] {
+	if len(nodes) == 0 {
+		return nil
+	}
+	mid := len(nodes) / 2
+	n := &nodes[mid]
+	n.left = buildTree(nodes[:mid])
+	n.right = buildTree(nodes[mid+1:])
+	n.update()
+	return n
+}
+
+// Compare compares two keys by the order of this map.
+func (m *Map[K, V]) Compare(a, b K) int {
+	return m.compare(a, b)
+}
+
+// CompareFunc returns the func used to order keys of this map.
+func (m *Map[K, V]) CompareFunc() func(a, b K) int {
+	return m.compare
+}
+
+// Contains returns true if key exists.
+func (m *Map[K, V]) Contains(k K) bool {
+	return m.find(k) != nil
+}
+
+// Get returns value for key.
+func (m *Map[K, V]) Get(k K) optional.Optional[V] {
+	if n := m.find(k); n != nil {
+		return optional.OfValue(n.v)
+	}
+	return optional.Empty[V]()
+}
+
+// Put adds or sets value for key.
+func (m *Map[K, V]) Put(k K, v V) {
+	m.root = m.put(m.root, k, v)
+}
+
+// PutMap adds/sets all key-values in another map.
+func (m *Map[K, V]) PutMap(another *Map[K, V]) {
+	for k, v := range another.All() {
+		m.Put(k, v)
+	}
+}
+
+func (m *Map[K, V]) put(n *node[K, V], k K, v V) *node[K, V] {
+	if n == nil {
+		m.modCount++
+		return &node[K, V]{k: k, v: v, height: 1, size: 1}
+	}
+	c := m.compare(k, n.k)
+	if c < 0 {
+		n.left = m.put(n.left, k, v)
+	} else if c > 0 {
+		n.right = m.put(n.right, k, v)
+	} else {
+		n.v = v
+		return n
+	}
+	return rebalance(n)
+}
+
+// Remove removes key.
+func (m *Map[K, V]) Remove(k K) {
+	m.root = m.remove(m.root, k)
+}
+
+// RemoveAll removes all keys.
+func (m *Map[K, V]) RemoveAll(keys ...K) {
+	for _, k := range keys {
+		m.Remove(k)
+	}
+}
+
+func (m *Map[K, V]) remove(n *node[K, V], k K) *node[K, V] {
+	if n == nil {
+		return nil
+	}
+	c := m.compare(k, n.k)
+	if c < 0 {
+		n.left = m.remove(n.left, k)
+	} else if c > 0 {
+		n.right = m.remove(n.right, k)
+	} else {
+		m.modCount++
+		if n.left == nil {
+			return n.right
+		}
+		if n.right == nil {
+			return n.left
+		}
+		right, successor := removeMin(n.right)
+		successor.left = n.left
+		successor.right = right
+		n = successor
+	}
+	return rebalance(n)
+}
+
+// removeMin removes the min node from the sub tree, returns the new root of sub tree and the removed node.
+func removeMin[K, V any](n *node[K, V]) (*node[K, V], *node[K, V]) {
+	if n.left == nil {
+		return n.right, n
+	}
+	left, min := removeMin(n.left)
+	n.left = left
+	return rebalance(n), min
+}
+
+// DeleteFunc removes all key-values for which del returns true.
+func (m *Map[K, V]) DeleteFunc(del func(K, V) bool) {
+	var keys []K
+	for k, v := range m.All() {
+		if del(k, v) {
+			keys = append(keys, k)
+		}
+	}
+	m.RemoveAll(keys...)
+}
+
+// Floor returns the entry with the greatest key less than or equal to the given key.
+func (m *Map[K, V]) Floor(k K) optional.Optional[pair.Pair[K, V]] {
+	return entryOf(m.floor(k, true))
+}
+
+// Ceiling returns the entry with the least key greater than or equal to the given key.
+func (m *Map[K, V]) Ceiling(k K) optional.Optional[pair.Pair[K, V]] {
+	return entryOf(m.ceiling(k, true))
+}
+
+// Lower returns the entry with the greatest key strictly less than the given key.
+func (m *Map[K, V]) Lower(k K) optional.Optional[pair.Pair[K, V]] {
+	return entryOf(m.floor(k, false))
+}
+
+// Higher returns the entry with the least key strictly greater than the given key.
+func (m *Map[K, V]) Higher(k K) optional.Optional[pair.Pair[K, V]] {
+	return entryOf(m.ceiling(k, false))
+}
+
+// Min returns the entry with the least key.
+func (m *Map[K, V]) Min() optional.Optional[pair.Pair[K, V]] {
+	return entryOf(m.min())
+}
+
+// Max returns the entry with the greatest key.
+func (m *Map[K, V]) Max() optional.Optional[pair.Pair[K, V]] {
+	return entryOf(m.max())
+}
+
+// PopMin removes and returns the entry with the least key.
+func (m *Map[K, V]) PopMin() optional.Optional[pair.Pair[K, V]] {
+	n := m.min()
+	if n != nil {
+		m.Remove(n.k)
+	}
+	return entryOf(n)
+}
+
+// PopMax removes and returns the entry with the greatest key.
+func (m *Map[K, V]) PopMax() optional.Optional[pair.Pair[K, V]] {
+	n := m.max()
+	if n != nil {
+		m.Remove(n.k)
+	}
+	return entryOf(n)
+}
+
+// Rank returns the number of keys strictly less than the given key.
+// If the key exists in map, it is the index of the key in ascending order.
+func (m *Map[K, V]) Rank(k K) int {
+	rank := 0
+	n := m.root
+	for n != nil {
+		c := m.compare(k, n.k)
+		if c <= 0 {
+			n = n.left
+		} else {
+			rank += size(n.left) + 1
+			n = n.right
+		}
+	}
+	return rank
+}
+
+// Select returns the entry at given index in ascending order of keys.
+// It returns an empty optional if index is out of range.
+func (m *Map[K, V]) Select(index int) optional.Optional[pair.Pair[K, V]] {
+	if index < 0 || index >= m.Size() {
+		return optional.Empty[pair.Pair[K, V]]()
+	}
+	n := m.root
+	for {
+		leftSize := size(n.left)
+		if index < leftSize {
+			n = n.left
+		} else if index > leftSize {
+			index -= leftSize + 1
+			n = n.right
+		} else {
+			return entryOf(n)
+		}
+	}
+}
+
+// Copy returns a new TreeMap with same key-values and same order.
+func (m *Map[K, V]) Copy() *Map[K, V] {
+	return &Map[K, V]{root: copyNode(m.root), compare: m.compare}
+}
+
+func copyNode[K, V any](n *node[K, V]) *node[K, V] {
+	if n == nil {
+		return nil
+	}
+	nn := *n
+	nn.left = copyNode(n.left)
+	nn.right = copyNode(n.right)
+	return &nn
+}
+
+// All returns all key-value pairs as a sequence, in ascending order of keys.
+func (m *Map[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for n := range m.ascend(optional.Empty[K](), false) {
+			if !yield(n.k, n.v) {
+				break
+			}
+		}
+	}
+}
+
+// Keys returns all keys as a sequence, in ascending order.
+func (m *Map[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for n := range m.ascend(optional.Empty[K](), false) {
+			if !yield(n.k) {
+				break
+			}
+		}
+	}
+}
+
+// Values returns all values as a sequence, in ascending order of keys.
+func (m *Map[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for n := range m.ascend(optional.Empty[K](), false) {
+			if !yield(n.v) {
+				break
+			}
+		}
+	}
+}
+
+// Backward returns all key-value pairs as a sequence, in descending order of keys.
+func (m *Map[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for n := range m.descend(optional.Empty[K](), false) {
+			if !yield(n.k, n.v) {
+				break
+			}
+		}
+	}
+}
+
+// Range returns the key-value pairs with from <= key < to as a sequence, in ascending order of keys.
+func (m *Map[K, V]) Range(from, to K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for n := range m.ascend(optional.OfValue(from), true) {
+			if m.compare(n.k, to) >= 0 || !yield(n.k, n.v) {
+				break
+			}
+		}
+	}
+}
+
+// RangeFrom returns the key-value pairs with key >= from as a sequence, in ascending order of keys.
+func (m *Map[K, V]) RangeFrom(from K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for n := range m.ascend(optional.OfValue(from), true) {
+			if !yield(n.k, n.v) {
+				break
+			}
+		}
+	}
+}
+
+// RangeTo returns the key-value pairs with key < to as a sequence, in ascending order of keys.
+func (m *Map[K, V]) RangeTo(to K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for n := range m.ascend(optional.Empty[K](), false) {
+			if m.compare(n.k, to) >= 0 || !yield(n.k, n.v) {
+				break
+			}
+		}
+	}
+}
+
+// Size returns the size of the map.
+func (m *Map[K, V]) Size() int {
+	return size(m.root)
+}
+
+// Clear clears the map.
+func (m *Map[K, V]) Clear() {
+	m.root = nil
+	m.modCount++
+}
+
+func (m *Map[K, V]) find(k K) *node[K, V] {
+	n := m.root
+	for n != nil {
+		c := m.compare(k, n.k)
+		if c < 0 {
+			n = n.left
+		} else if c > 0 {
+			n = n.right
+		} else {
+			return n
+		}
+	}
+	return nil
+}
+
+func (m *Map[K, V]) floor(k K, inclusive bool) *node[K, V] {
+	var r *node[K, V]
+	n := m.root
+	for n != nil {
+		c := m.compare(n.k, k)
+		if c < 0 || inclusive && c == 0 {
+			r = n
+			n = n.right
+		} else {
+			n = n.left
+		}
+	}
+	return r
+}
+
+func (m *Map[K, V]) ceiling(k K, inclusive bool) *node[K, V] {
+	var r *node[K, V]
+	n := m.root
+	for n != nil {
+		c := m.compare(n.k, k)
+		if c > 0 || inclusive && c == 0 {
+			r = n
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+	return r
+}
+
+func (m *Map[K, V]) min() *node[K, V] {
+	n := m.root
+	for n != nil && n.left != nil {
+		n = n.left
+	}
+	return n
+}
+
+func (m *Map[K, V]) max() *node[K, V] {
+	n := m.root
+	for n != nil && n.right != nil {
+		n = n.right
+	}
+	return n
+}
+
+// ceilingPath appends the nodes with key greater than (or equal to, if inclusive) k along the search path to stack.
+// The top of the stack is the ceiling node. If k is empty, all nodes on the left spine are appended.
+func (m *Map[K, V]) ceilingPath(stack []*node[K, V], k optional.Optional[K], inclusive bool) []*node[K, V] {
+	n := m.root
+	for n != nil {
+		if k.IsEmpty() {
+			stack = append(stack, n)
+			n = n.left
+			continue
+		}
+		c := m.compare(n.k, k.Get())
+		if c > 0 || inclusive && c == 0 {
+			stack = append(stack, n)
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+	return stack
+}
+
+// floorPath appends the nodes with key less than (or equal to, if inclusive) k along the search path to stack.
+// The top of the stack is the floor node. If k is empty, all nodes on the right spine are appended.
+func (m *Map[K, V]) floorPath(stack []*node[K, V], k optional.Optional[K], inclusive bool) []*node[K, V] {
+	n := m.root
+	for n != nil {
+		if k.IsEmpty() {
+			stack = append(stack, n)
+			n = n.right
+			continue
+		}
+		c := m.compare(n.k, k.Get())
+		if c < 0 || inclusive && c == 0 {
+			stack = append(stack, n)
+			n = n.right
+		} else {
+			n = n.left
+		}
+	}
+	return stack
+}
+
+// ascend returns a sequence of nodes in ascending order, starting from the ceiling node of from.
+// If from is empty, starts from the min node.
+func (m *Map[K, V]) ascend(from optional.Optional[K], inclusive bool) iter.Seq[*node[K, V]] {
+	return m.traverse(from, inclusive, m.ceilingPath,
+		func(n *node[K, V]) *node[K, V] { return n.right },
+		func(n *node[K, V]) *node[K, V] { return n.left })
+}
+
+// descend returns a sequence of nodes in descending order, starting from the floor node of from.
+// If from is empty, starts from the max node.
+func (m *Map[K, V]) descend(from optional.Optional[K], inclusive bool) iter.Seq[*node[K, V]] {
+	return m.traverse(from, inclusive, m.floorPath,
+		func(n *node[K, V]) *node[K, V] { return n.left },
+		func(n *node[K, V]) *node[K, V] { return n.right })
+}
+
+// traverse walks the tree in-order using a stack. When the node just yielded is removed, the traversal re-seeks
+// the next node by its key; other structural modifications cause a panic.
+func (m *Map[K, V]) traverse(
+	from optional.Optional[K], inclusive bool,
+	path func([]*node[K, V], optional.Optional[K], bool) []*node[K, V],
+	forward, backward func(*node[K, V]) *node[K, V],
+) iter.Seq[*node[K, V]] {
+	return func(yield func(*node[K, V]) bool) {
+		expected := m.modCount
+		stack := path(nil, from, inclusive)
+		for len(stack) > 0 {
+			n := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for c := forward(n); c != nil; c = backward(c) {
+				stack = append(stack, c)
+			}
+			if !yield(n) {
+				break
+			}
+			if m.modCount != expected {
+				if m.modCount != expected+1 || m.find(n.k) != nil {
+					panic(ErrorConcurrentModification)
+				}
+				// only the current node has been removed
+				expected = m.modCount
+				stack = path(stack[:0], optional.OfValue(n.k), false)
+			}
+		}
+	}
+}
+
+func entryOf[K, V any](n *node[K, V]) optional.Optional[pair.Pair[K, V]] {
+	if n == nil {
+		return optional.Empty[pair.Pair[K, V]]()
+	}
+	return optional.OfValue(pair.Of(n.k, n.v))
+}
+
+func height[K, V any](n *node[K, V]) int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func size[K, V any](n *node[K, V]) int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (n *node[K, V]) update() {
+	n.height = 1 + max(height(n.left), height(n.right))
+	n.size = 1 + size(n.left) + size(n.right)
+}
+
+func rotateLeft[K, V any](n *node[K, V]) *node[K, V] {
+	r := n.right
+	n.right = r.left
+	r.left = n
+	n.update()
+	r.update()
+	return r
+}
+
+func rotateRight[K, V any](n *node[K, V]) *node[K, V] {
+	l := n.left
+	n.left = l.right
+	l.right = n
+	n.update()
+	l.update()
+	return l
+}
+
+// rebalance updates the node and restores the AVL balance, returns the new root of sub tree.
+func rebalance[K, V any](n *node[K, V]) *node[K, V] {
+	n.update()
+	balance := height(n.left) - height(n.right)
+	if balance > 1 {
+		if height(n.left.left) < height(n.left.right) {
+			n.left = rotateLeft(n.left)
+		}
+		return rotateRight(n)
+	}
+	if balance < -1 {
+		if height(n.right.right) < height(n.right.left) {
+			n.right = rotateRight(n.right)
+		}
+		return rotateLeft(n)
+	}
+	return n
+}
@@ -0,0 +1,216 @@
+package treemap
+
+import (
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hsiafan/go-utils/collection/pair"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTreeMap_PutAndRemove(t *testing.T) {
+	m := New[int, string]()
+	m.Put(3, "3")
+	m.Put(1, "1")
+	m.Put(4, "4")
+	m.Put(2, "2")
+	value, ok := m.Get(1).Unwrap()
+	assert.True(t, ok)
+	assert.Equal(t, "1", value)
+	assert.True(t, m.Get(5).IsEmpty())
+
+	m.Put(1, "one")
+	assert.Equal(t, "one", m.Get(1).Get())
+
+	m.Remove(2)
+	assert.Equal(t, 3, m.Size())
+	assert.False(t, m.Contains(2))
+	assert.Equal(t, []int{1, 3, 4}, slices.Collect(m.Keys()))
+	assert.Equal(t, []string{"one", "3", "4"}, slices.Collect(m.Values()))
+}
+
+func TestTreeMap_Random(t *testing.T) {
+	m := New[int, int]()
+	model := map[int]int{}
+	for i := 0; i < 5000; i++ {
+		k := rand.IntN(500)
+		if rand.IntN(3) == 0 {
+			m.Remove(k)
+			delete(model, k)
+		} else {
+			m.Put(k, i)
+			model[k] = i
+		}
+	}
+	keys := make([]int, 0, len(model))
+	for k := range model {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	assert.Equal(t, len(model), m.Size())
+	assert.Equal(t, keys, slices.Collect(m.Keys()))
+	for i, k := range keys {
+		assert.Equal(t, model[k], m.Get(k).Get())
+		assert.Equal(t, i, m.Rank(k))
+		assert.Equal(t, k, m.Select(i).Get().Key())
+	}
+	assert.LessOrEqual(t, m.root.height, 2*bitsLen(m.Size()))
+}
+
+func bitsLen(n int) int {
+	l := 0
+	for ; n > 0; n >>= 1 {
+		l++
+	}
+	return l
+}
+
+func TestTreeMap_Navigation(t *testing.T) {
+	m := New[int, string]()
+	for _, k := range []int{10, 20, 30, 40} {
+		m.Put(k, "")
+	}
+
+	assert.Equal(t, 20, m.Floor(20).Get().Key())
+	assert.Equal(t, 20, m.Floor(25).Get().Key())
+	assert.True(t, m.Floor(5).IsEmpty())
+	assert.Equal(t, 20, m.Ceiling(20).Get().Key())
+	assert.Equal(t, 30, m.Ceiling(25).Get().Key())
+	assert.True(t, m.Ceiling(45).IsEmpty())
+	assert.Equal(t, 10, m.Lower(20).Get().Key())
+	assert.True(t, m.Lower(10).IsEmpty())
+	assert.Equal(t, 30, m.Higher(20).Get().Key())
+	assert.True(t, m.Higher(40).IsEmpty())
+
+	assert.Equal(t, 10, m.Min().Get().Key())
+	assert.Equal(t, 40, m.Max().Get().Key())
+	assert.Equal(t, 2, m.Rank(25))
+	assert.Equal(t, 0, m.Rank(1))
+	assert.Equal(t, 4, m.Rank(100))
+	assert.True(t, m.Select(-1).IsEmpty())
+	assert.True(t, m.Select(4).IsEmpty())
+
+	assert.Equal(t, pair.Of(10, ""), m.PopMin().Get())
+	assert.Equal(t, pair.Of(40, ""), m.PopMax().Get())
+	assert.Equal(t, []int{20, 30}, slices.Collect(m.Keys()))
+
+	m.Clear()
+	assert.True(t, m.Min().IsEmpty())
+	assert.True(t, m.PopMin().IsEmpty())
+}
+
+func TestTreeMap_RangeAndBackward(t *testing.T) {
+	m := New[int, int]()
+	for i := 0; i < 10; i++ {
+		m.Put(i, i*i)
+	}
+
+	var keys []int
+	for k := range m.Range(3, 7) {
+		keys = append(keys, k)
+	}
+	assert.Equal(t, []int{3, 4, 5, 6}, keys)
+
+	keys = nil
+	for k := range m.Range(7, 3) {
+		keys = append(keys, k)
+	}
+	assert.Empty(t, keys)
+
+	keys = nil
+	for k := range m.RangeFrom(7) {
+		keys = append(keys, k)
+	}
+	assert.Equal(t, []int{7, 8, 9}, keys)
+	keys = nil
+	for k := range m.RangeTo(3) {
+		keys = append(keys, k)
+	}
+	assert.Equal(t, []int{0, 1, 2}, keys)
+
+	keys = nil
+	for k, v := range m.Backward() {
+		assert.Equal(t, k*k, v)
+		keys = append(keys, k)
+		if k == 6 {
+			break
+		}
+	}
+	assert.Equal(t, []int{9, 8, 7, 6}, keys)
+}
+
+func TestTreeMap_Func(t *testing.T) {
+	m := NewFunc[string, int](strings.Compare)
+	m.Put("b", 2)
+	m.Put("a", 1)
+	m.Put("c", 3)
+	assert.Equal(t, []string{"a", "b", "c"}, slices.Collect(m.Keys()))
+
+	r := NewFunc[int, int](func(a, b int) int { return b - a })
+	r.Put(1, 1)
+	r.Put(3, 3)
+	r.Put(2, 2)
+	assert.Equal(t, []int{3, 2, 1}, slices.Collect(r.Keys()))
+}
+
+func TestTreeMap_Copy(t *testing.T) {
+	m := New[int, int]()
+	m.Put(1, 1)
+	m.Put(2, 2)
+
+	nm := m.Copy()
+	nm.Put(3, 3)
+	assert.Equal(t, 2, m.Size())
+	assert.Equal(t, []int{1, 2, 3}, slices.Collect(nm.Keys()))
+
+	m.PutMap(nm)
+	assert.Equal(t, 3, m.Size())
+}
+
+func TestTreeMap_ModifyWhileIterating(t *testing.T) {
+	m := New[int, int]()
+	for i := 0; i < 10; i++ {
+		m.Put(i, i)
+	}
+	for k := range m.Keys() {
+		if k%2 == 0 {
+			m.Remove(k)
+		}
+	}
+	assert.Equal(t, []int{1, 3, 5, 7, 9}, slices.Collect(m.Keys()))
+
+	for k := range m.Backward() {
+		if k > 3 {
+			m.Remove(k)
+		}
+	}
+	assert.Equal(t, []int{1, 3}, slices.Collect(m.Keys()))
+
+	assert.PanicsWithValue(t, ErrorConcurrentModification, func() {
+		for range m.All() {
+			m.Put(100, 100)
+		}
+	})
+
+	m.DeleteFunc(func(k, v int) bool {
+		return k > 1
+	})
+	assert.Equal(t, []int{1}, slices.Collect(m.Keys()))
+}
+
+func TestCollect(t *testing.T) {
+	m := Collect(slices.All([]string{"a", "b", "c", "d", "e"}))
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, slices.Collect(m.Keys()))
+	assert.Equal(t, 3, m.root.height)
+	assert.Equal(t, "c", m.Select(2).Get().Value())
+
+	r := Collect(slices.All([]string{"a", "b", "c"}))
+	r.Put(-1, "z")
+	assert.Equal(t, []int{-1, 0, 1, 2}, slices.Collect(r.Keys()))
+
+	u := CollectFunc(slices.Backward([]string{"a", "b", "c"}), func(a, b int) int { return a - b })
+	assert.Equal(t, []string{"a", "b", "c"}, slices.Collect(u.Values()))
+}
@@ -0,0 +1,257 @@
+package trie
+
+import (
+	"iter"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hsiafan/go-utils/collection/pair"
+	"github.com/hsiafan/go-utils/lang/optional"
+)
+
+// node is a radix tree node. A node without value always has at least two children, except the root.
+type node[V any] struct {
+	prefix   string // edge label from the parent node
+	value    V
+	hasValue bool
+	children []*node[V] // sorted by first byte of prefix, first bytes are distinct
+}
+
+// child returns the index of child starting with byte c, and the child; if no such child,
+// returns the index where it should be inserted, and nil.
+func (n *node[V]) child(c byte) (int, *node[V]) {
+	i := sort.Search(len(n.children), func(i int) bool { return n.children[i].prefix[0] >= c })
+	if i < len(n.children) && n.children[i].prefix[0] == c {
+		return i, n.children[i]
+	}
+	return i, nil
+}
+
+// mergeChild merges the only child into this node.
+func (n *node[V]) mergeChild() {
+	c := n.children[0]
+	n.prefix += c.prefix
+	n.value = c.value
+	n.hasValue = c.hasValue
+	n.children = c.children
+}
+
+// Trie is a map with string keys, implemented by a compressed radix tree, supports prefix lookup.
+// Keys are ordered lexically byte-wise, as strings are compared in Go.
+//
+// The zero Trie is empty and ready for use. The Trie should not be modified during iteration.
+// Lookups do not change a Trie, so they may run concurrently, but not with Put, Delete or Clear.
+type Trie[V any] struct {
+	root node[V]
+	size int
+}
+
+// New creates a new empty Trie.
+func New[V any]() *Trie[V] {
+	return &Trie[V]{}
+}
+
+// Put adds or sets value for key.
+func (t *Trie[V]) Put(key string, v V) {
+	n := &t.root
+	s := key
+	for s != "" {
+		i, c := n.child(s[0])
+		if c == nil {
+			n.children = append(n.children, nil)
+			copy(n.children[i+1:], n.children[i:])
+			n.children[i] = &node[V]{prefix: s, value: v, hasValue: true}
+			t.size++
+			return
+		}
+		l := commonPrefixLen(s, c.prefix)
+		if l < len(c.prefix) {
+			// split the edge
+			mid := &node[V]{prefix: c.prefix[:l], children: []*node[V]{c}}
+			c.prefix = c.prefix[l:]
+			n.children[i] = mid
+			c = mid
+		}
+		n = c
+		s = s[l:]
+	}
+	if !n.hasValue {
+		t.size++
+	}
+	n.value = v
+	n.hasValue = true
+}
+
+// Get returns the value for key.
+func (t *Trie[V]) Get(key string) optional.Optional[V] {
+	n := t.find(key)
+	if n == nil {
+		return optional.Empty[V]()
+	}
+	return optional.Of(n.value, n.hasValue)
+}
+
+// Contains reports whether the key exists.
+func (t *Trie[V]) Contains(key string) bool {
+	n := t.find(key)
+	return n != nil && n.hasValue
+}
+
+// find returns the node for key, or nil if not found.
+func (t *Trie[V]) find(key string) *node[V] {
+	n := &t.root
+	s := key
+	for s != "" {
+		_, c := n.child(s[0])
+		if c == nil || !strings.HasPrefix(s, c.prefix) {
+			return nil
+		}
+		n = c
+		s = s[len(c.prefix):]
+	}
+	return n
+}
+
+// Delete removes the key, returns true if the key existed.
+func (t *Trie[V]) Delete(key string) bool {
+	var parent *node[V]
+	var index int
+	n := &t.root
+	s := key
+	for s != "" {
+		i, c := n.child(s[0])
+		if c == nil || !strings.HasPrefix(s, c.prefix) {
+			return false
+		}
+		parent, index, n = n, i, c
+		s = s[len(c.prefix):]
+	}
+	if !n.hasValue {
+		return false
+	}
+	var zero V
+	n.value = zero
+	n.hasValue = false
+	t.size--
+
+	if parent == nil {
+		return true
+	}
+	switch len(n.children) {
+	case 0:
+		parent.children = slices.Delete(parent.children, index, index+1)
+		if parent != &t.root && !parent.hasValue && len(parent.children) == 1 {
+			parent.mergeChild()
+		}
+	case 1:
+		n.mergeChild()
+	}
+	return true
+}
+
+// LongestPrefix returns the entry whose key is the longest prefix of s.
+func (t *Trie[V]) LongestPrefix(s string) optional.Optional[pair.Pair[string, V]] {
+	var found *node[V]
+	var foundLen int
+	n := &t.root
+	consumed := 0
+	for {
+		if n.hasValue {
+			found, foundLen = n, consumed
+		}
+		if consumed == len(s) {
+			break
+		}
+		_, c := n.child(s[consumed])
+		if c == nil || !strings.HasPrefix(s[consumed:], c.prefix) {
+			break
+		}
+		n = c
+		consumed += len(c.prefix)
+	}
+	if found == nil {
+		return optional.Empty[pair.Pair[string, V]]()
+	}
+	return optional.OfValue(pair.Of(s[:foundLen], found.value))
+}
+
+// WithPrefix returns all entries whose keys start with prefix as a [iter.Seq2], in lexical order of keys.
+func (t *Trie[V]) WithPrefix(prefix string) iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		n := &t.root
+		s := prefix
+		var key []byte
+		for s != "" {
+			_, c := n.child(s[0])
+			if c == nil {
+				return
+			}
+			if strings.HasPrefix(s, c.prefix) {
+				s = s[len(c.prefix):]
+			} else if strings.HasPrefix(c.prefix, s) {
+				s = ""
+			} else {
+				return
+			}
+			key = append(key, c.prefix...)
+			n = c
+		}
+		walk(n, key, yield)
+	}
+}
+
+// walk visits entries of the subtree in lexical order, key is the key of node n.
+func walk[V any](n *node[V], key []byte, yield func(string, V) bool) bool {
+	if n.hasValue && !yield(string(key), n.value) {
+		return false
+	}
+	for _, c := range n.children {
+		if !walk(c, append(key, c.prefix...), yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// All returns all entries as a [iter.Seq2], in lexical order of keys.
+func (t *Trie[V]) All() iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		walk(&t.root, nil, yield)
+	}
+}
+
+// Keys returns all keys as a [iter.Seq], in lexical order.
+func (t *Trie[V]) Keys() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		walk(&t.root, nil, func(k string, _ V) bool {
+			return yield(k)
+		})
+	}
+}
+
+// Walk calls fn for each entry in lexical order of keys, until fn returns false.
+func (t *Trie[V]) Walk(fn func(key string, v V) bool) {
+	walk(&t.root, nil, fn)
+}
+
+// Size returns the number of keys.
+func (t *Trie[V]) Size() int {
+	return t.size
+}
+
+// Clear removes all keys.
+func (t *Trie[V]) Clear() {
+	t.root = node[V]{}
+	t.size = 0
+}
+
+func commonPrefixLen(a, b string) int {
+	n := min(len(a), len(b))
+	for i := 0; i < n; i++ {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+	return n
+}
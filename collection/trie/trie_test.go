@@ -0,0 +1,213 @@
+package trie
+
+import (
+	"iter"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hsiafan/go-utils/collection/pair"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrie(t *testing.T) {
+	var tr Trie[int]
+	tr.Put("romane", 1)
+	tr.Put("romanus", 2)
+	tr.Put("romulus", 3)
+	tr.Put("rubens", 4)
+	tr.Put("ruber", 5)
+	tr.Put("rubicon", 6)
+	tr.Put("rubicundus", 7)
+	tr.Put("", 0)
+	tr.Put("rom", 8)
+	assert.Equal(t, 9, tr.Size())
+
+	assert.Equal(t, 2, tr.Get("romanus").Get())
+	assert.Equal(t, 0, tr.Get("").Get())
+	assert.True(t, tr.Get("roman").IsEmpty())
+	assert.True(t, tr.Get("romanusx").IsEmpty())
+	assert.True(t, tr.Contains("rom"))
+	assert.False(t, tr.Contains("ro"))
+
+	tr.Put("rom", 80)
+	assert.Equal(t, 9, tr.Size())
+	assert.Equal(t, 80, tr.Get("rom").Get())
+
+	assert.Equal(t, []string{"", "rom", "romane", "romanus", "romulus", "rubens", "ruber", "rubicon", "rubicundus"},
+		slices.Collect(tr.Keys()))
+	assert.Equal(t, []string{"rubicon", "rubicundus"}, slices.Collect(keys(tr.WithPrefix("rubic"))))
+	assert.Equal(t, []string{"rubicon", "rubicundus"}, slices.Collect(keys(tr.WithPrefix("rubi"))))
+	assert.Equal(t, []string{"rom", "romane", "romanus", "romulus"}, slices.Collect(keys(tr.WithPrefix("ro"))))
+	assert.Empty(t, slices.Collect(keys(tr.WithPrefix("rx"))))
+	assert.Empty(t, slices.Collect(keys(tr.WithPrefix("romanex"))))
+	assert.Equal(t, 9, len(slices.Collect(keys(tr.WithPrefix("")))))
+
+	assert.Equal(t, pair.Of("romane", 1), tr.LongestPrefix("romanesque").Get())
+	assert.Equal(t, pair.Of("rom", 80), tr.LongestPrefix("romanx").Get())
+	assert.Equal(t, pair.Of("", 0), tr.LongestPrefix("x").Get())
+
+	var walked []string
+	tr.Walk(func(key string, v int) bool {
+		walked = append(walked, key)
+		return len(walked) < 3
+	})
+	assert.Equal(t, []string{"", "rom", "romane"}, walked)
+
+	assert.True(t, tr.Delete(""))
+	assert.False(t, tr.Delete(""))
+	assert.True(t, tr.LongestPrefix("x").IsEmpty())
+	assert.False(t, tr.Delete("roman"))
+	assert.True(t, tr.Delete("romane"))
+	assert.True(t, tr.Delete("rom"))
+	assert.Equal(t, []string{"romanus", "romulus"}, slices.Collect(keys(tr.WithPrefix("ro"))))
+	assert.Equal(t, 6, tr.Size())
+
+	tr.Clear()
+	assert.Equal(t, 0, tr.Size())
+	assert.Empty(t, slices.Collect(tr.Keys()))
+}
+
+func TestTrie_Random(t *testing.T) {
+	tr := New[int]()
+	model := map[string]int{}
+	r := rand.New(rand.NewPCG(1, 2))
+	randomKey := func() string {
+		var sb strings.Builder
+		for n := r.IntN(6); n > 0; n-- {
+			sb.WriteByte("abc"[r.IntN(3)])
+		}
+		return sb.String()
+	}
+	for i := 0; i < 5000; i++ {
+		key := randomKey()
+		if r.IntN(3) == 0 {
+			_, ok := model[key]
+			delete(model, key)
+			assert.Equal(t, ok, tr.Delete(key))
+		} else {
+			model[key] = i
+			tr.Put(key, i)
+		}
+		assert.Equal(t, len(model), tr.Size())
+		checkNode(t, &tr.root, true)
+	}
+
+	assert.Equal(t, slices.Sorted(maps.Keys(model)), slices.Collect(tr.Keys()))
+	for k, v := range model {
+		assert.Equal(t, v, tr.Get(k).Get())
+	}
+	for i := 0; i < 100; i++ {
+		prefix := randomKey()
+		var expected []string
+		longest := ""
+		found := false
+		for k := range model {
+			if strings.HasPrefix(k, prefix) {
+				expected = append(expected, k)
+			}
+			if strings.HasPrefix(prefix, k) && len(k) >= len(longest) {
+				longest, found = k, true
+			}
+		}
+		slices.Sort(expected)
+		assert.Equal(t, expected, slices.Collect(keys(tr.WithPrefix(prefix))))
+		lp, ok := tr.LongestPrefix(prefix).Unwrap()
+		assert.Equal(t, found, ok)
+		if ok {
+			assert.Equal(t, longest, lp.Key())
+		}
+	}
+}
+
+// checkNode checks the radix tree is compressed, and children are sorted.
+func checkNode[V any](t *testing.T, n *node[V], root bool) {
+	if !root {
+		assert.NotEmpty(t, n.prefix)
+		assert.True(t, n.hasValue || len(n.children) >= 2, "node %q is not compressed", n.prefix)
+	}
+	for i, c := range n.children {
+		if i > 0 {
+			assert.Less(t, n.children[i-1].prefix[0], c.prefix[0])
+		}
+		checkNode(t, c, false)
+	}
+}
+
+func keys[K, V any](seq iter.Seq2[K, V]) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range seq {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+func benchmarkKeys() []string {
+	r := rand.New(rand.NewPCG(1, 2))
+	var keys []string
+	for i := 0; i < 10000; i++ {
+		keys = append(keys, "/api/v"+strconv.Itoa(r.IntN(3))+"/resource"+strconv.Itoa(r.IntN(100))+"/"+strconv.Itoa(i))
+	}
+	return keys
+}
+
+func BenchmarkTrie_WithPrefix(b *testing.B) {
+	tr := New[int]()
+	for i, k := range benchmarkKeys() {
+		tr.Put(k, i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for range tr.WithPrefix("/api/v1/resource42/") {
+		}
+	}
+}
+
+func BenchmarkMap_WithPrefix(b *testing.B) {
+	m := map[string]int{}
+	for i, k := range benchmarkKeys() {
+		m[k] = i
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for k := range m {
+			if strings.HasPrefix(k, "/api/v1/resource42/") {
+				_ = k
+			}
+		}
+	}
+}
+
+func BenchmarkTrie_LongestPrefix(b *testing.B) {
+	tr := New[int]()
+	for i, k := range benchmarkKeys() {
+		tr.Put(k, i)
+	}
+	path := "/api/v1/resource42/123456/detail"
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tr.LongestPrefix(path)
+	}
+}
+
+func BenchmarkMap_LongestPrefix(b *testing.B) {
+	m := map[string]int{}
+	for i, k := range benchmarkKeys() {
+		m[k] = i
+	}
+	path := "/api/v1/resource42/123456/detail"
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		longest := ""
+		for k := range m {
+			if strings.HasPrefix(path, k) && len(k) > len(longest) {
+				longest = k
+			}
+		}
+	}
+}
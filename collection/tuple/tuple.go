@@ -0,0 +1,173 @@
+package tuple
+
+import "cmp"
+
+// Triple is a tuple of three values.
+type Triple[A, B, C any] struct {
+	first  A
+	second B
+	third  C
+}
+
+// Of3 creates a new Triple.
+func Of3[A, B, C any](first A, second B, third C) Triple[A, B, C] {
+	return Triple[A, B, C]{first, second, third}
+}
+
+// Unpack unpacks triple to its values.
+func (t Triple[A, B, C]) Unpack() (A, B, C) {
+	return t.first, t.second, t.third
+}
+
+// First returns the first value of triple.
+func (t Triple[A, B, C]) First() A {
+	return t.first
+}
+
+// Second returns the second value of triple.
+func (t Triple[A, B, C]) Second() B {
+	return t.second
+}
+
+// Third returns the third value of triple.
+func (t Triple[A, B, C]) Third() C {
+	return t.third
+}
+
+// Quad is a tuple of four values.
+type Quad[A, B, C, D any] struct {
+	first  A
+	second B
+	third  C
+	fourth D
+}
+
+// Of4 creates a new Quad.
+func Of4[A, B, C, D any](first A, second B, third C, fourth D) Quad[A, B, C, D] {
+	return Quad[A, B, C, D]{first, second, third, fourth}
+}
+
+// Unpack unpacks quad to its values.
+func (q Quad[A, B, C, D]) Unpack() (A, B, C, D) {
+	return q.first, q.second, q.third, q.fourth
+}
+
+// First returns the first value of quad.
+func (q Quad[A, B, C, D]) First() A {
+	return q.first
+}
+
+// Second returns the second value of quad.
+func (q Quad[A, B, C, D]) Second() B {
+	return q.second
+}
+
+// Third returns the third value of quad.
+func (q Quad[A, B, C, D]) Third() C {
+	return q.third
+}
+
+// Fourth returns the fourth value of quad.
+func (q Quad[A, B, C, D]) Fourth() D {
+	return q.fourth
+}
+
+// CompareTriple compares two triples lexicographically.
+// It returns a negative number when a < b, a positive number when a > b and zero when a == b.
+func CompareTriple[A, B, C cmp.Ordered](a, b Triple[A, B, C]) int {
+	if c := cmp.Compare(a.first, b.first); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.second, b.second); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.third, b.third)
+}
+
+// CompareQuad compares two quads lexicographically.
+// It returns a negative number when a < b, a positive number when a > b and zero when a == b.
+func CompareQuad[A, B, C, D cmp.Ordered](a, b Quad[A, B, C, D]) int {
+	if c := cmp.Compare(a.first, b.first); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.second, b.second); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.third, b.third); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.fourth, b.fourth)
+}
+
+// CompareTripleFunc returns a func compares two triples lexicographically, by compareFirst, compareSecond
+// then by compareThird.
+func CompareTripleFunc[A, B, C any](compareFirst func(A, A) int, compareSecond func(B, B) int,
+	compareThird func(C, C) int) func(a, b Triple[A, B, C]) int {
+	return func(a, b Triple[A, B, C]) int {
+		if c := compareFirst(a.first, b.first); c != 0 {
+			return c
+		}
+		if c := compareSecond(a.second, b.second); c != 0 {
+			return c
+		}
+		return compareThird(a.third, b.third)
+	}
+}
+
+// CompareQuadFunc returns a func compares two quads lexicographically, by compareFirst, compareSecond,
+// compareThird then by compareFourth.
+func CompareQuadFunc[A, B, C, D any](compareFirst func(A, A) int, compareSecond func(B, B) int,
+	compareThird func(C, C) int, compareFourth func(D, D) int) func(a, b Quad[A, B, C, D]) int {
+	return func(a, b Quad[A, B, C, D]) int {
+		if c := compareFirst(a.first, b.first); c != 0 {
+			return c
+		}
+		if c := compareSecond(a.second, b.second); c != 0 {
+			return c
+		}
+		if c := compareThird(a.third, b.third); c != 0 {
+			return c
+		}
+		return compareFourth(a.fourth, b.fourth)
+	}
+}
+
+// Zip3 makes triples of values at the same index. If the slices have different lengths,
+// the extra elements of the longer ones are ignored.
+func Zip3[A, B, C any](as []A, bs []B, cs []C) []Triple[A, B, C] {
+	n := min(len(as), len(bs), len(cs))
+	ts := make([]Triple[A, B, C], n)
+	for i := 0; i < n; i++ {
+		ts[i] = Triple[A, B, C]{as[i], bs[i], cs[i]}
+	}
+	return ts
+}
+
+// Unzip3 splits triples into three slices.
+func Unzip3[A, B, C any](ts []Triple[A, B, C]) ([]A, []B, []C) {
+	as, bs, cs := make([]A, len(ts)), make([]B, len(ts)), make([]C, len(ts))
+	for i, t := range ts {
+		as[i], bs[i], cs[i] = t.first, t.second, t.third
+	}
+	return as, bs, cs
+}
+
+// Zip4 makes quads of values at the same index. If the slices have different lengths,
+// the extra elements of the longer ones are ignored.
+func Zip4[A, B, C, D any](as []A, bs []B, cs []C, ds []D) []Quad[A, B, C, D] {
+	n := min(len(as), len(bs), len(cs), len(ds))
+	qs := make([]Quad[A, B, C, D], n)
+	for i := 0; i < n; i++ {
+		qs[i] = Quad[A, B, C, D]{as[i], bs[i], cs[i], ds[i]}
+	}
+	return qs
+}
+
+// Unzip4 splits quads into four slices.
+func Unzip4[A, B, C, D any](qs []Quad[A, B, C, D]) ([]A, []B, []C, []D) {
+	as, bs, cs, ds := make([]A, len(qs)), make([]B, len(qs)), make([]C, len(qs)), make([]D, len(qs))
+	for i, q := range qs {
+		as[i], bs[i], cs[i], ds[i] = q.first, q.second, q.third, q.fourth
+	}
+	return as, bs, cs, ds
+}
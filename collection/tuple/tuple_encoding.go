@@ -0,0 +1,57 @@
+package tuple
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// MarshalJSON encodes the triple as a JSON array of three elements.
+func (t Triple[A, B, C]) MarshalJSON() ([]byte, error) {
+	return json.Marshal([3]any{t.first, t.second, t.third})
+}
+
+// UnmarshalJSON decodes a JSON array of three elements into the triple. A JSON null is a no-op.
+func (t *Triple[A, B, C]) UnmarshalJSON(data []byte) error {
+	var decoded Triple[A, B, C]
+	ok, err := unmarshalArray(data, &decoded.first, &decoded.second, &decoded.third)
+	if ok {
+		*t = decoded
+	}
+	return err
+}
+
+// MarshalJSON encodes the quad as a JSON array of four elements.
+func (q Quad[A, B, C, D]) MarshalJSON() ([]byte, error) {
+	return json.Marshal([4]any{q.first, q.second, q.third, q.fourth})
+}
+
+// UnmarshalJSON decodes a JSON array of four elements into the quad. A JSON null is a no-op.
+func (q *Quad[A, B, C, D]) UnmarshalJSON(data []byte) error {
+	var decoded Quad[A, B, C, D]
+	ok, err := unmarshalArray(data, &decoded.first, &decoded.second, &decoded.third, &decoded.fourth)
+	if ok {
+		*q = decoded
+	}
+	return err
+}
+
+// unmarshalArray decodes a JSON array into the targets element by element.
+// It returns true if the array is fully decoded, and false without error for a JSON null.
+func unmarshalArray(data []byte, targets ...any) (bool, error) {
+	var values []json.RawMessage
+	if err := json.Unmarshal(data, &values); err != nil {
+		return false, err
+	}
+	if values == nil {
+		return false, nil
+	}
+	if len(values) != len(targets) {
+		return false, fmt.Errorf("tuple: expect JSON array of %d elements, got %d", len(targets), len(values))
+	}
+	for i, v := range values {
+		if err := json.Unmarshal(v, targets[i]); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
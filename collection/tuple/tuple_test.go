@@ -0,0 +1,92 @@
+package tuple
+
+import (
+	"cmp"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTriple(t *testing.T) {
+	tr := Of3("a", 1, true)
+	a, b, c := tr.Unpack()
+	assert.Equal(t, "a", a)
+	assert.Equal(t, 1, b)
+	assert.True(t, c)
+	assert.Equal(t, "a", tr.First())
+	assert.Equal(t, 1, tr.Second())
+	assert.True(t, tr.Third())
+
+	ts := []Triple[int, string, int]{Of3(2, "a", 1), Of3(1, "b", 2), Of3(1, "a", 3), Of3(1, "a", 2)}
+	slices.SortFunc(ts, CompareTriple)
+	assert.Equal(t, []Triple[int, string, int]{Of3(1, "a", 2), Of3(1, "a", 3), Of3(1, "b", 2), Of3(2, "a", 1)}, ts)
+
+	as, bs, cs := Unzip3(ts)
+	assert.Equal(t, []int{1, 1, 1, 2}, as)
+	assert.Equal(t, []string{"a", "a", "b", "a"}, bs)
+	assert.Equal(t, []int{2, 3, 2, 1}, cs)
+	assert.Equal(t, ts, Zip3(as, bs, cs))
+	assert.Equal(t, ts[:2], Zip3(as, bs[:2], cs))
+
+	// descending by the second, then ascending by the others
+	byLen := func(a, b string) int { return cmp.Compare(len(a), len(b)) }
+	slices.SortFunc(ts, CompareTripleFunc(cmp.Compare[int], func(a, b string) int { return strings.Compare(b, a) },
+		cmp.Compare[int]))
+	assert.Equal(t, []Triple[int, string, int]{Of3(1, "b", 2), Of3(1, "a", 2), Of3(1, "a", 3), Of3(2, "a", 1)}, ts)
+	compare := CompareTripleFunc(byLen, byLen, byLen)
+	assert.Equal(t, 0, compare(Of3("a", "b", "c"), Of3("x", "y", "z")))
+	assert.Negative(t, compare(Of3("a", "b", "c"), Of3("x", "y", "zz")))
+}
+
+func TestQuad(t *testing.T) {
+	q := Of4("a", 1, true, 2.5)
+	a, b, c, d := q.Unpack()
+	assert.Equal(t, "a", a)
+	assert.Equal(t, 1, b)
+	assert.True(t, c)
+	assert.Equal(t, 2.5, d)
+	assert.Equal(t, 2.5, q.Fourth())
+
+	assert.Equal(t, 0, CompareQuad(Of4(1, 2, 3, 4), Of4(1, 2, 3, 4)))
+	assert.Negative(t, CompareQuad(Of4(1, 2, 3, 4), Of4(1, 2, 3, 5)))
+	assert.Positive(t, CompareQuad(Of4(1, 3, 0, 0), Of4(1, 2, 3, 4)))
+
+	compare := CompareQuadFunc(cmp.Compare[int], cmp.Compare[int], cmp.Compare[int],
+		func(a, b []int) int { return cmp.Compare(len(a), len(b)) })
+	assert.Equal(t, 0, compare(Of4(1, 2, 3, []int{1}), Of4(1, 2, 3, []int{2})))
+	assert.Negative(t, compare(Of4(1, 2, 3, []int{}), Of4(1, 2, 3, []int{2})))
+	assert.Positive(t, compare(Of4(1, 2, 4, []int{}), Of4(1, 2, 3, []int{2})))
+
+	qs := Zip4([]int{1, 2}, []string{"a", "b", "c"}, []bool{true, false}, []float64{1.5, 2.5})
+	assert.Equal(t, []Quad[int, string, bool, float64]{Of4(1, "a", true, 1.5), Of4(2, "b", false, 2.5)}, qs)
+	as, bs, cs, ds := Unzip4(qs)
+	assert.Equal(t, []int{1, 2}, as)
+	assert.Equal(t, []string{"a", "b"}, bs)
+	assert.Equal(t, []bool{true, false}, cs)
+	assert.Equal(t, []float64{1.5, 2.5}, ds)
+	assert.Empty(t, Zip4([]int{}, bs, cs, ds))
+}
+
+func TestTuple_JSON(t *testing.T) {
+	data, err := json.Marshal(Of3("a", 1, []int{2}))
+	assert.NoError(t, err)
+	assert.Equal(t, `["a",1,[2]]`, string(data))
+
+	var tr Triple[string, int, []int]
+	assert.NoError(t, json.Unmarshal(data, &tr))
+	assert.Equal(t, Of3("a", 1, []int{2}), tr)
+	assert.NoError(t, json.Unmarshal([]byte("null"), &tr))
+	assert.Equal(t, Of3("a", 1, []int{2}), tr)
+	assert.Error(t, json.Unmarshal([]byte(`["a",1]`), &tr))
+	assert.Error(t, json.Unmarshal([]byte(`["a","b",[]]`), &tr))
+
+	data, err = json.Marshal(Of4[int, string, bool, *int](1, "b", false, nil))
+	assert.NoError(t, err)
+	assert.Equal(t, `[1,"b",false,null]`, string(data))
+	var q Quad[int, string, bool, *int]
+	assert.NoError(t, json.Unmarshal(data, &q))
+	assert.Equal(t, Of4[int, string, bool, *int](1, "b", false, nil), q)
+}
@@ -0,0 +1,160 @@
+package unionfind
+
+import (
+	"iter"
+)
+
+// UnionFind is a disjoint-set structure, which keeps elements partitioned into disjoint groups,
+// and supports merging groups and finding the group of an element.
+// It uses path compression and union by rank, so operations take nearly constant amortized time.
+//
+// The zero UnionFind is empty and ready for use.
+// A UnionFind is not safe for concurrent use, even Find and Connected modify it to compress paths.
+type UnionFind[T comparable] struct {
+	index  map[T]int // element to its id
+	values []T       // id to element, in the order elements are added
+	parent []int
+	rank   []uint8
+	count  int // number of groups
+}
+
+// New creates a new UnionFind, each value is in a group by itself.
+func New[T comparable](values ...T) *UnionFind[T] {
+	u := &UnionFind[T]{}
+	for _, v := range values {
+		u.Add(v)
+	}
+	return u
+}
+
+// Add adds a value in a new group by itself, returns false if the value already exists.
+func (u *UnionFind[T]) Add(v T) bool {
+	if _, ok := u.index[v]; ok {
+		return false
+	}
+	u.id(v)
+	return true
+}
+
+// id returns the id of value, adds the value if not exists.
+func (u *UnionFind[T]) id(v T) int {
+	if i, ok := u.index[v]; ok {
+		return i
+	}
+	if u.index == nil {
+		u.index = make(map[T]int)
+	}
+	i := len(u.values)
+	u.index[v] = i
+	u.values = append(u.values, v)
+	u.parent = append(u.parent, i)
+	u.rank = append(u.rank, 0)
+	u.count++
+	return i
+}
+
+// root returns the root id of the group of id i, and compresses the path.
+func (u *UnionFind[T]) root(i int) int {
+	r := i
+	for u.parent[r] != r {
+		r = u.parent[r]
+	}
+	for u.parent[i] != r {
+		u.parent[i], i = r, u.parent[i]
+	}
+	return r
+}
+
+// Union merges the groups of a and b, values not exist are added first.
+// It returns false if a and b are already in the same group.
+func (u *UnionFind[T]) Union(a, b T) bool {
+	ra, rb := u.root(u.id(a)), u.root(u.id(b))
+	if ra == rb {
+		return false
+	}
+	switch {
+	case u.rank[ra] < u.rank[rb]:
+		u.parent[ra] = rb
+	case u.rank[ra] > u.rank[rb]:
+		u.parent[rb] = ra
+	default:
+		u.parent[rb] = ra
+		u.rank[ra]++
+	}
+	u.count--
+	return true
+}
+
+// Find returns the representative element of the group of value. All elements in the same group
+// have the same representative, until the group is merged with another group.
+// If the value not exists, it is returned as is.
+func (u *UnionFind[T]) Find(v T) T {
+	i, ok := u.index[v]
+	if !ok {
+		return v
+	}
+	return u.values[u.root(i)]
+}
+
+// Connected reports whether a and b are in the same group. It returns false if either value not exists.
+func (u *UnionFind[T]) Connected(a, b T) bool {
+	ia, ok := u.index[a]
+	if !ok {
+		return false
+	}
+	ib, ok := u.index[b]
+	if !ok {
+		return false
+	}
+	return u.root(ia) == u.root(ib)
+}
+
+// Contains reports whether the value has been added.
+func (u *UnionFind[T]) Contains(v T) bool {
+	_, ok := u.index[v]
+	return ok
+}
+
+// Groups returns all groups as a [iter.Seq], each group is a new slice.
+// Groups are ordered by their earliest added element, and elements in a group are in the order they were added.
+func (u *UnionFind[T]) Groups() iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		groups := make(map[int]int, u.count) // root id to index in result
+		result := make([][]T, 0, u.count)
+		for i, v := range u.values {
+			r := u.root(i)
+			g, ok := groups[r]
+			if !ok {
+				g = len(result)
+				groups[r] = g
+				result = append(result, nil)
+			}
+			result[g] = append(result[g], v)
+		}
+		for _, group := range result {
+			if !yield(group) {
+				break
+			}
+		}
+	}
+}
+
+// Count returns the number of groups.
+func (u *UnionFind[T]) Count() int {
+	return u.count
+}
+
+// Size returns the number of elements.
+func (u *UnionFind[T]) Size() int {
+	return len(u.values)
+}
+
+// Clear removes all elements.
+func (u *UnionFind[T]) Clear() {
+	clear(u.index)
+	clear(u.values)
+	u.values = u.values[:0]
+	u.parent = u.parent[:0]
+	u.rank = u.rank[:0]
+	u.count = 0
+}
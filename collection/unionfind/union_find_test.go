@@ -0,0 +1,93 @@
+package unionfind
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnionFind(t *testing.T) {
+	u := New("a", "b", "c")
+	assert.Equal(t, 3, u.Count())
+	assert.False(t, u.Add("a"))
+	assert.True(t, u.Union("a", "b"))
+	assert.False(t, u.Union("b", "a"))
+	assert.True(t, u.Union("d", "e"))
+	assert.Equal(t, 5, u.Size())
+	assert.Equal(t, 3, u.Count())
+
+	assert.True(t, u.Connected("a", "b"))
+	assert.False(t, u.Connected("a", "c"))
+	assert.True(t, u.Connected("c", "c"))
+	assert.False(t, u.Connected("x", "x"))
+	assert.False(t, u.Connected("x", "a"))
+	assert.Equal(t, u.Find("a"), u.Find("b"))
+	assert.Equal(t, "x", u.Find("x"))
+	assert.False(t, u.Contains("x"))
+
+	assert.Equal(t, [][]string{{"a", "b"}, {"c"}, {"d", "e"}}, slices.Collect(u.Groups()))
+	assert.True(t, u.Union("e", "c"))
+	assert.Equal(t, [][]string{{"a", "b"}, {"c", "d", "e"}}, slices.Collect(u.Groups()))
+	for g := range u.Groups() {
+		assert.Equal(t, []string{"a", "b"}, g)
+		break
+	}
+
+	u.Clear()
+	assert.Equal(t, 0, u.Count())
+	assert.Equal(t, 0, u.Size())
+	assert.Empty(t, slices.Collect(u.Groups()))
+}
+
+func TestUnionFind_ZeroValue(t *testing.T) {
+	var u UnionFind[int]
+	assert.Equal(t, 0, u.Count())
+	assert.False(t, u.Connected(1, 2))
+	u.Union(1, 2)
+	assert.True(t, u.Connected(1, 2))
+	assert.Equal(t, 1, u.Count())
+}
+
+func TestUnionFind_Random(t *testing.T) {
+	const n = 200
+	r := rand.New(rand.NewPCG(1, 2))
+	u := New[int]()
+	// model: label of each element's group
+	label := make([]int, n)
+	for i := range label {
+		label[i] = i
+		u.Add(i)
+	}
+	for step := 0; step < 300; step++ {
+		a, b := r.IntN(n), r.IntN(n)
+		merged := label[a] != label[b]
+		assert.Equal(t, merged, u.Union(a, b))
+		if merged {
+			old := label[b]
+			for i := range label {
+				if label[i] == old {
+					label[i] = label[a]
+				}
+			}
+		}
+		c, d := r.IntN(n), r.IntN(n)
+		assert.Equal(t, label[c] == label[d], u.Connected(c, d))
+	}
+
+	groups := map[int]bool{}
+	for _, l := range label {
+		groups[l] = true
+	}
+	assert.Equal(t, len(groups), u.Count())
+	total := 0
+	for g := range u.Groups() {
+		total += len(g)
+		for _, v := range g {
+			assert.Equal(t, label[g[0]], label[v])
+			assert.Equal(t, u.Find(g[0]), u.Find(v))
+		}
+	}
+	assert.Equal(t, n, total)
+}
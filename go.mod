@@ -1,6 +1,6 @@
 module github.com/hsiafan/go-utils
 
-go 1.23
+go 1.24
 
 require github.com/stretchr/testify v1.9.0
 